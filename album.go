@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+// albumItem representa uma foto aguardando o envio em álbum
+type albumItem struct {
+	image   []byte
+	caption string
+	camera  string
+	eventID string
+	opts    telegram_handler.SendOptions
+	// onSent é chamado após o envio com a mensagem da foto, a legenda usada e se ela faz parte de um álbum
+	onSent func(msg telegram_handler.SentMessage, caption string, album bool)
+}
+
+// albumKey identifica o destino de um álbum
+type albumKey struct {
+	chatID   int64
+	threadID int64
+}
+
+// albumBatcher agrupa as fotos de eventos que chegam dentro da mesma janela de tempo
+// em um único álbum do Telegram. As fotos são separadas por chat e thread de destino.
+type albumBatcher struct {
+	mu      sync.Mutex
+	window  time.Duration
+	tgBot   telegram_handler.Telegram
+	pending map[albumKey][]albumItem
+	windows map[albumKey]*albumWindow // janela em andamento de cada destino
+}
+
+// albumWindow é a janela de agrupamento em andamento de um destino
+type albumWindow struct {
+	timer *time.Timer
+}
+
+// newAlbumBatcher cria um novo agrupador de álbuns com a janela informada
+func newAlbumBatcher(bot telegram_handler.Telegram, window time.Duration) *albumBatcher {
+	return &albumBatcher{
+		window:  window,
+		tgBot:   bot,
+		pending: make(map[albumKey][]albumItem),
+		windows: make(map[albumKey]*albumWindow),
+	}
+}
+
+// Add adiciona uma foto ao álbum do destino informado. O primeiro item inicia a janela;
+// o álbum é enviado ao fim da janela ou quando atingir o limite de fotos do Telegram.
+func (a *albumBatcher) Add(key albumKey, item albumItem) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending[key] = append(a.pending[key], item)
+
+	if len(a.pending[key]) >= telegram_handler.MaxAlbumSize {
+		go a.send(a.take(key))
+		return
+	}
+
+	if len(a.pending[key]) == 1 {
+		window := &albumWindow{}
+		window.timer = time.AfterFunc(a.window, func() { a.flush(key, window) })
+		a.windows[key] = window
+	}
+}
+
+// take remove e retorna as fotos pendentes de um destino, encerrando a janela em andamento.
+// Deve ser chamado com o mutex travado.
+func (a *albumBatcher) take(key albumKey) []albumItem {
+	items := a.pending[key]
+	delete(a.pending, key)
+	if window, ok := a.windows[key]; ok {
+		window.timer.Stop()
+		delete(a.windows, key)
+	}
+	return items
+}
+
+// flush envia as fotos pendentes de um destino ao fim da janela informada. O timer de uma janela
+// já encerrada (álbum enviado ao atingir o limite) não envia as fotos da janela seguinte.
+func (a *albumBatcher) flush(key albumKey, window *albumWindow) {
+	a.mu.Lock()
+	if a.windows[key] != window {
+		a.mu.Unlock()
+		return
+	}
+	items := a.take(key)
+	a.mu.Unlock()
+
+	if len(items) > 0 {
+		a.send(items)
+	}
+}
+
+// send envia as fotos como um álbum com a legenda combinada de todos os eventos
+func (a *albumBatcher) send(items []albumItem) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Uma foto sozinha é enviada normalmente, com os botões de ação do evento
+	if len(items) == 1 {
+		item := items[0]
+		sent, err := a.tgBot.SendPhoto(ctx, item.image, item.caption, item.camera, item.opts)
+		if err != nil {
+			log.Printf("Erro ao enviar foto para o Telegram: %v", err)
+			return
+		}
+		log.Printf("Foto do evento %s enviada para o Telegram.", item.eventID)
+		if item.onSent != nil {
+			item.onSent(sent, item.caption, false)
+		}
+		return
+	}
+
+	photos := make([][]byte, 0, len(items))
+	captions := make([]string, 0, len(items))
+	eventIDs := make([]string, 0, len(items))
+	silent := true
+	for _, item := range items {
+		photos = append(photos, item.image)
+		captions = append(captions, item.caption)
+		eventIDs = append(eventIDs, item.eventID)
+		// O álbum só é silencioso se todos os eventos forem silenciosos
+		silent = silent && item.opts.Silent
+	}
+
+	// Todos os itens têm o mesmo destino
+	opts := telegram_handler.SendOptions{
+		Silent:   silent,
+		ChatID:   items[0].opts.ChatID,
+		ThreadID: items[0].opts.ThreadID,
+	}
+	caption := strings.Join(captions, "\n\n")
+	sent, err := a.tgBot.SendAlbum(ctx, photos, caption, items[0].camera, opts)
+	if err != nil {
+		log.Printf("Erro ao enviar álbum para o Telegram: %v", err)
+		return
+	}
+	log.Printf("Álbum com %d fotos enviado para o Telegram (eventos: %s).", len(items), strings.Join(eventIDs, ", "))
+
+	for i, item := range items {
+		if item.onSent == nil {
+			continue
+		}
+		// A legenda do álbum fica apenas no primeiro item
+		itemCaption := ""
+		if i == 0 {
+			itemCaption = caption
+		}
+		item.onSent(sent[i], itemCaption, true)
+	}
+}
+
+// albumKeyFor retorna o destino da foto de uma câmera, usado para separar os álbuns
+func (h *AppHandler) albumKeyFor(cameraName string, opts telegram_handler.SendOptions) albumKey {
+	key := albumKey{chatID: opts.ChatID, threadID: opts.ThreadID}
+	if key.threadID != 0 || key.chatID != 0 || !h.cfg.UseThreadIDs {
+		return key
+	}
+	for _, group := range h.cfg.Groups {
+		if group.Name == cameraName {
+			key.threadID = group.ID
+			return key
+		}
+	}
+	key.threadID = h.cfg.TelegramChatID
+	return key
+}
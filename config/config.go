@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -15,6 +18,310 @@ type Group struct {
 	ID   int64
 }
 
+// LabelFilter define listas de labels permitidas (allow) e bloqueadas (deny)
+type LabelFilter struct {
+	Allow []string `mapstructure:"allow"`
+	Deny  []string `mapstructure:"deny"`
+}
+
+// merge combina o filtro com o de uma câmera: a lista allow da câmera substitui a atual
+// quando definida e as listas deny são somadas
+func (f LabelFilter) merge(other LabelFilter) LabelFilter {
+	result := LabelFilter{
+		Allow: f.Allow,
+		Deny:  append(append([]string{}, f.Deny...), other.Deny...),
+	}
+	if len(other.Allow) > 0 {
+		result.Allow = other.Allow
+	}
+	return result
+}
+
+// ZoneFilter define as zonas do Frigate em que o objeto precisa estar para gerar notificação
+type ZoneFilter struct {
+	Required []string `mapstructure:"required"`
+	Match    string   `mapstructure:"match"`  // "any" (padrão) ou "all"
+	Source   string   `mapstructure:"source"` // "entered" (padrão) ou "current"
+}
+
+// validate verifica se os campos do ZoneFilter são válidos (vazio = padrão)
+func (z ZoneFilter) validate() error {
+	switch z.Match {
+	case "", "any", "all":
+	default:
+		return fmt.Errorf("zones.match inválido: '%s' (use any ou all)", z.Match)
+	}
+	switch z.Source {
+	case "", "entered", "current":
+	default:
+		return fmt.Errorf("zones.source inválido: '%s' (use entered ou current)", z.Source)
+	}
+	return nil
+}
+
+// Ações possíveis de um Schedule
+const (
+	ScheduleNotify = "notify" // notificação com som
+	ScheduleSilent = "silent" // notificação silenciosa (disable_notification)
+	ScheduleDrop   = "drop"   // não envia nada
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "dom": time.Sunday,
+	"mon": time.Monday, "seg": time.Monday,
+	"tue": time.Tuesday, "ter": time.Tuesday,
+	"wed": time.Wednesday, "qua": time.Wednesday,
+	"thu": time.Thursday, "qui": time.Thursday,
+	"fri": time.Friday, "sex": time.Friday,
+	"sat": time.Saturday, "sab": time.Saturday,
+}
+
+// Schedule define uma janela de horário semanal e a ação tomada para eventos dentro dela
+type Schedule struct {
+	Labels []string `mapstructure:"labels"` // vazio = todas as labels
+	Days   []string `mapstructure:"days"`   // mon..sun ou seg..dom, vazio = todos os dias
+	Start  string   `mapstructure:"start"`  // HH:MM, vazio = 00:00
+	End    string   `mapstructure:"end"`    // HH:MM, vazio = 24:00; pode ser menor que start (passa da meia-noite)
+	Action string   `mapstructure:"action"` // notify, silent ou drop
+}
+
+// parseClock converte HH:MM em minutos desde a meia-noite. "24:00" é aceito como o fim do dia.
+func parseClock(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil || hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("horário inválido: %s", value)
+	}
+	return hour*60 + minute, nil
+}
+
+// validate verifica se os campos do Schedule são válidos
+func (s Schedule) validate() error {
+	switch s.Action {
+	case ScheduleNotify, ScheduleSilent, ScheduleDrop:
+	default:
+		return fmt.Errorf("ação inválida no schedule: '%s'", s.Action)
+	}
+	for _, day := range s.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("dia inválido no schedule: '%s'", day)
+		}
+	}
+	if _, err := parseClock(s.Start, 0); err != nil {
+		return err
+	}
+	if _, err := parseClock(s.End, 24*60); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Matches verifica se a label e o horário informados estão dentro da janela
+func (s Schedule) Matches(label string, t time.Time) bool {
+	if len(s.Labels) > 0 {
+		found := false
+		for _, l := range s.Labels {
+			if strings.EqualFold(l, label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	start, _ := parseClock(s.Start, 0)
+	end, _ := parseClock(s.End, 24*60)
+	now := t.Hour()*60 + t.Minute()
+
+	// Para janelas que passam da meia-noite, o dia considerado é o do início da janela
+	day := t.Weekday()
+	inWindow := now >= start && now < end
+	if start > end {
+		inWindow = now >= start || now < end
+		if now < end {
+			day = (day + 6) % 7
+		}
+	}
+	if !inWindow {
+		return false
+	}
+
+	if len(s.Days) == 0 {
+		return true
+	}
+	for _, d := range s.Days {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterConfig agrupa os filtros aplicados aos eventos antes do envio ao Telegram.
+// É usado tanto como padrão global quanto dentro de cada câmera.
+type FilterConfig struct {
+	Labels    LabelFilter `mapstructure:"labels"`
+	SubLabels LabelFilter `mapstructure:"sub_labels"` // nomes de rostos ou sub labels definidos pelo Frigate
+	Plates    LabelFilter `mapstructure:"plates"`     // placas reconhecidas
+	Zones     ZoneFilter  `mapstructure:"zones"`
+
+	MinScore           float64            `mapstructure:"min_score"`            // score mínimo (0 a 1) para qualquer label
+	MinScores          map[string]float64 `mapstructure:"min_scores"`           // score mínimo por label
+	SkipFalsePositives *bool              `mapstructure:"skip_false_positives"` // ignora eventos marcados como falso positivo
+	SkipStationary     *bool              `mapstructure:"skip_stationary"`      // ignora objetos parados
+
+	Schedules []Schedule `mapstructure:"schedules"` // janelas de horário avaliadas em ordem
+
+	Cooldown  time.Duration            `mapstructure:"cooldown"`  // intervalo mínimo entre notificações da mesma câmera+label
+	Cooldowns map[string]time.Duration `mapstructure:"cooldowns"` // cooldown por label
+}
+
+// CooldownFor retorna o cooldown para uma label, priorizando o valor específico da label
+func (f FilterConfig) CooldownFor(label string) time.Duration {
+	if cooldown, ok := f.Cooldowns[strings.ToLower(label)]; ok {
+		return cooldown
+	}
+	return f.Cooldown
+}
+
+// ScheduleAction retorna a ação do primeiro schedule que corresponde à label e ao horário.
+// Sem schedules correspondentes, o evento é notificado normalmente.
+func (f FilterConfig) ScheduleAction(label string, t time.Time) string {
+	for _, schedule := range f.Schedules {
+		if schedule.Matches(label, t) {
+			return schedule.Action
+		}
+	}
+	return ScheduleNotify
+}
+
+// MinScoreFor retorna o score mínimo para uma label, priorizando o valor específico da label
+func (f FilterConfig) MinScoreFor(label string) float64 {
+	if score, ok := f.MinScores[strings.ToLower(label)]; ok {
+		return score
+	}
+	return f.MinScore
+}
+
+// Rule representa uma regra de notificação avaliada em ordem sobre os campos do evento.
+// When é uma expressão booleana (ex.: `camera == "Garagem" && label == "car" && hour >= 22`).
+type Rule struct {
+	Name     string   `mapstructure:"name"`
+	When     string   `mapstructure:"when"`
+	Actions  []string `mapstructure:"actions"`   // photo, clip, silent, drop
+	ChatID   int64    `mapstructure:"chat_id"`   // chat de destino (0 = chat padrão)
+	ThreadID int64    `mapstructure:"thread_id"` // thread de destino (0 = thread da câmera)
+}
+
+// Fontes de eventos suportadas
+const (
+	EventSourceEvents  = "events"  // objetos rastreados (frigate/events)
+	EventSourceReviews = "reviews" // review items do Frigate 0.14+ (frigate/reviews)
+)
+
+// ReviewConfig define como os review items são notificados
+type ReviewConfig struct {
+	Severities        []string `mapstructure:"severities"`          // severidades notificadas: alert, detection
+	DetectionsSilent  bool     `mapstructure:"detections_silent"`   // envia detections sem som
+	AlertThreadID     int64    `mapstructure:"alert_thread_id"`     // thread dos alerts (0 = thread da câmera)
+	DetectionThreadID int64    `mapstructure:"detection_thread_id"` // thread das detections (0 = thread da câmera)
+}
+
+// Modos de publicação das descrições da GenAI
+const (
+	GenAIModeEdit  = "edit"  // adiciona a descrição à legenda da foto
+	GenAIModeReply = "reply" // responde a foto com a descrição
+)
+
+// GenAIConfig define o tratamento das descrições geradas pela GenAI do Frigate (tracked_object_update)
+type GenAIConfig struct {
+	Enabled  bool     `mapstructure:"enabled"`
+	Mode     string   `mapstructure:"mode"`     // edit ou reply
+	Keywords []string `mapstructure:"keywords"` // se preenchido, só publica descrições com alguma das palavras
+}
+
+// HealthConfig define o monitoramento de disponibilidade do Frigate e das câmeras
+type HealthConfig struct {
+	Enabled             bool          `mapstructure:"enabled"`
+	PollInterval        time.Duration `mapstructure:"poll_interval"`         // intervalo de consulta de /api/stats
+	FrigateOfflineAfter time.Duration `mapstructure:"frigate_offline_after"` // tempo sem resposta da API para considerar o Frigate offline
+	CameraOfflineAfter  time.Duration `mapstructure:"camera_offline_after"`  // tempo com fps zerado para considerar a câmera offline
+}
+
+// StorageConfig define o monitoramento do disco de gravações do Frigate
+type StorageConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	PollInterval time.Duration `mapstructure:"poll_interval"` // intervalo de consulta
+	Path         string        `mapstructure:"path"`          // ponto de montagem monitorado (service.storage em /api/stats)
+	Thresholds   []float64     `mapstructure:"thresholds"`    // percentuais de uso que geram alerta
+	SummaryDay   string        `mapstructure:"summary_day"`   // dia do resumo semanal (mon..sun ou seg..dom, vazio = desativado)
+	SummaryTime  string        `mapstructure:"summary_time"`  // horário do resumo semanal (HH:MM)
+}
+
+// SummaryDue verifica se o resumo semanal deve ser enviado no horário informado
+func (s StorageConfig) SummaryDue(t time.Time) bool {
+	day, ok := weekdays[strings.ToLower(s.SummaryDay)]
+	if !ok || t.Weekday() != day {
+		return false
+	}
+	at, err := parseClock(s.SummaryTime, 0)
+	if err != nil {
+		return false
+	}
+	return t.Hour()*60+t.Minute() >= at
+}
+
+// ModeConfig define um modo da casa (ex.: home, away, night), ativado com /arm.
+// Com um modo ativo, apenas as câmeras e labels do modo geram notificações.
+type ModeConfig struct {
+	Name    string   `mapstructure:"name"`
+	Cameras []string `mapstructure:"cameras"` // câmeras que notificam (vazio = todas)
+	Labels  []string `mapstructure:"labels"`  // labels que notificam (vazio = todas)
+	Clips   *bool    `mapstructure:"clips"`   // envia os clipes (padrão: true)
+}
+
+// Allows verifica se o modo permite notificações da câmera e label informadas
+func (m ModeConfig) Allows(camera, label string) bool {
+	return m.AllowsCamera(camera) && (len(m.Labels) == 0 || containsFold(m.Labels, label))
+}
+
+// AllowsCamera verifica se o modo permite notificações da câmera
+func (m ModeConfig) AllowsCamera(camera string) bool {
+	return len(m.Cameras) == 0 || containsFold(m.Cameras, camera)
+}
+
+// SendClips indica se o modo envia os clipes dos eventos
+func (m ModeConfig) SendClips() bool {
+	return m.Clips == nil || *m.Clips
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthConfig define quem pode usar os comandos do bot.
+// Sem admins configurados, os usuários dos chats permitidos são apenas viewers.
+type AuthConfig struct {
+	Admins  []int64 `mapstructure:"admins"`  // IDs de usuários com acesso total
+	Viewers []int64 `mapstructure:"viewers"` // IDs de usuários sem acesso aos comandos administrativos
+	Chats   []int64 `mapstructure:"chats"`   // chats permitidos além do telegram_chat_id e dos chat_id das regras
+}
+
+// CameraConfig representa as configurações específicas de uma câmera
+type CameraConfig struct {
+	Name         string `mapstructure:"name"`
+	FilterConfig `mapstructure:",squash"`
+}
+
 // Config struct para armazenar as configurações da aplicação
 // As tags 'mapstructure' agora correspondem às chaves no YAML
 type Config struct {
@@ -32,6 +339,141 @@ type Config struct {
 	TimezoneAjust  int     `mapstructure:"timezone_ajust"`
 	Groups         []Group `mapstructure:"-"`
 	CheckTelegram  bool    `mapstructure:"check_telegram"`
+
+	AlbumWindow time.Duration `mapstructure:"album_window"` // janela para agrupar fotos simultâneas em um álbum (0 = desativado)
+
+	EditSnapshots    bool    `mapstructure:"edit_snapshots"`      // substitui a foto enviada quando chega um snapshot melhor
+	EditMinScoreGain float64 `mapstructure:"edit_min_score_gain"` // ganho mínimo de top_score para substituir a foto
+
+	Filters FilterConfig   `mapstructure:"filters"`
+	Cameras []CameraConfig `mapstructure:"cameras"`
+	Rules   []Rule         `mapstructure:"rules"`
+
+	EventSource string        `mapstructure:"event_source"` // events ou reviews
+	Reviews     ReviewConfig  `mapstructure:"reviews"`
+	GenAI       GenAIConfig   `mapstructure:"genai"`
+	Health      HealthConfig  `mapstructure:"health"`
+	Storage     StorageConfig `mapstructure:"storage"`
+
+	Auth AuthConfig `mapstructure:"auth"`
+
+	Modes     []ModeConfig `mapstructure:"modes"`
+	ModeTopic string       `mapstructure:"mode_topic"` // tópico MQTT para trocar o modo (payload: nome do modo ou "disarm")
+}
+
+// TopicFor retorna um tópico MQTT do Frigate usando o mesmo prefixo de mqtt_topic
+// (ex.: "frigate/events" + "reviews" = "frigate/reviews")
+func (c *Config) TopicFor(name string) string {
+	prefix := "frigate"
+	if i := strings.LastIndex(c.MQTTTopic, "/"); i > 0 {
+		prefix = c.MQTTTopic[:i]
+	}
+	return prefix + "/" + name
+}
+
+// AllowedChats retorna os chats em que o bot atende comandos e botões além do telegram_chat_id:
+// os de auth.chats e os chats de destino das regras
+func (c *Config) AllowedChats() []int64 {
+	chats := append([]int64{}, c.Auth.Chats...)
+	for _, rule := range c.Rules {
+		if rule.ChatID != 0 && !slices.Contains(chats, rule.ChatID) {
+			chats = append(chats, rule.ChatID)
+		}
+	}
+	return chats
+}
+
+// GetCamera retorna a configuração da câmera pelo nome, ou nil se não existir
+func (c *Config) GetCamera(name string) *CameraConfig {
+	for i := range c.Cameras {
+		if c.Cameras[i].Name == name {
+			return &c.Cameras[i]
+		}
+	}
+	return nil
+}
+
+// GetMode retorna o modo pelo nome (sem diferenciar maiúsculas/minúsculas), ou nil se não existir
+func (c *Config) GetMode(name string) *ModeConfig {
+	return FindMode(c.Modes, name)
+}
+
+// FindMode procura um modo pelo nome (sem diferenciar maiúsculas/minúsculas), ou nil se não existir
+func FindMode(modes []ModeConfig, name string) *ModeConfig {
+	for i := range modes {
+		if strings.EqualFold(modes[i].Name, name) {
+			return &modes[i]
+		}
+	}
+	return nil
+}
+
+// FiltersFor retorna os filtros efetivos de uma câmera, combinando o padrão global
+// com as configurações específicas da câmera.
+func (c *Config) FiltersFor(camera string) FilterConfig {
+	result := FilterConfig{
+		Labels:             c.Filters.Labels.merge(LabelFilter{}),
+		SubLabels:          c.Filters.SubLabels.merge(LabelFilter{}),
+		Plates:             c.Filters.Plates.merge(LabelFilter{}),
+		Zones:              c.Filters.Zones,
+		MinScore:           c.Filters.MinScore,
+		MinScores:          make(map[string]float64),
+		SkipFalsePositives: c.Filters.SkipFalsePositives,
+		SkipStationary:     c.Filters.SkipStationary,
+		Schedules:          c.Filters.Schedules,
+		Cooldown:           c.Filters.Cooldown,
+		Cooldowns:          make(map[string]time.Duration),
+	}
+	for label, score := range c.Filters.MinScores {
+		result.MinScores[label] = score
+	}
+	for label, cooldown := range c.Filters.Cooldowns {
+		result.Cooldowns[label] = cooldown
+	}
+
+	cam := c.GetCamera(camera)
+	if cam == nil {
+		return result
+	}
+
+	result.Labels = result.Labels.merge(cam.Labels)
+	result.SubLabels = result.SubLabels.merge(cam.SubLabels)
+	result.Plates = result.Plates.merge(cam.Plates)
+
+	if len(cam.Zones.Required) > 0 {
+		result.Zones.Required = cam.Zones.Required
+	}
+	if cam.Zones.Match != "" {
+		result.Zones.Match = cam.Zones.Match
+	}
+	if cam.Zones.Source != "" {
+		result.Zones.Source = cam.Zones.Source
+	}
+
+	if cam.MinScore > 0 {
+		result.MinScore = cam.MinScore
+	}
+	for label, score := range cam.MinScores {
+		result.MinScores[label] = score
+	}
+	if cam.SkipFalsePositives != nil {
+		result.SkipFalsePositives = cam.SkipFalsePositives
+	}
+	if cam.SkipStationary != nil {
+		result.SkipStationary = cam.SkipStationary
+	}
+
+	if cam.Cooldown > 0 {
+		result.Cooldown = cam.Cooldown
+	}
+	for label, cooldown := range cam.Cooldowns {
+		result.Cooldowns[label] = cooldown
+	}
+
+	// Os schedules da câmera têm prioridade sobre os globais
+	result.Schedules = append(append([]Schedule{}, cam.Schedules...), c.Filters.Schedules...)
+
+	return result
 }
 
 // LoadConfig carrega as configurações de um arquivo config.yaml.
@@ -66,6 +508,24 @@ func LoadConfig() (*Config, error) {
 	v.SetDefault("use_thread_ids", false)
 	v.SetDefault("timezone_ajust", 0)
 	v.SetDefault("check_telegram", false)
+	v.SetDefault("album_window", "0s")
+	v.SetDefault("edit_snapshots", true)
+	v.SetDefault("edit_min_score_gain", 0.05)
+	v.SetDefault("event_source", EventSourceEvents)
+	v.SetDefault("reviews.severities", []string{"alert", "detection"})
+	v.SetDefault("genai.enabled", false)
+	v.SetDefault("genai.mode", GenAIModeEdit)
+	v.SetDefault("health.enabled", false)
+	v.SetDefault("health.poll_interval", "1m")
+	v.SetDefault("health.frigate_offline_after", "2m")
+	v.SetDefault("health.camera_offline_after", "5m")
+	v.SetDefault("storage.enabled", false)
+	v.SetDefault("storage.poll_interval", "15m")
+	v.SetDefault("storage.path", "/media/frigate/recordings")
+	v.SetDefault("storage.thresholds", []float64{80, 90, 95})
+	v.SetDefault("storage.summary_day", "sun")
+	v.SetDefault("storage.summary_time", "09:00")
+	v.SetDefault("mode_topic", "frigate-events-telegram/mode")
 
 	// Deserializar a configuração lida para a struct Config
 	var cfg Config
@@ -106,6 +566,84 @@ func LoadConfig() (*Config, error) {
 	}
 	// FrigateURL tem um padrão, então não precisa ser fatal se ausente no yaml
 
+	if cfg.EventSource != EventSourceEvents && cfg.EventSource != EventSourceReviews {
+		log.Printf("Erro: 'event_source' inválido: %s", cfg.EventSource)
+		return nil, fmt.Errorf("'event_source' inválido: %s", cfg.EventSource)
+	}
+
+	if cfg.GenAI.Mode != GenAIModeEdit && cfg.GenAI.Mode != GenAIModeReply {
+		log.Printf("Erro: 'genai.mode' inválido: %s", cfg.GenAI.Mode)
+		return nil, fmt.Errorf("'genai.mode' inválido: %s", cfg.GenAI.Mode)
+	}
+	// As descrições chegam por objeto rastreado, e as notificações dos reviews são guardadas por review
+	if cfg.GenAI.Enabled && cfg.EventSource == EventSourceReviews {
+		log.Println("Erro: 'genai.enabled' não é suportado com 'event_source: reviews'")
+		return nil, errors.New("'genai.enabled' não é suportado com 'event_source: reviews'")
+	}
+
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"health.poll_interval", cfg.Health.PollInterval},
+		{"health.frigate_offline_after", cfg.Health.FrigateOfflineAfter},
+		{"health.camera_offline_after", cfg.Health.CameraOfflineAfter},
+	} {
+		if d.value <= 0 {
+			log.Printf("Erro: '%s' deve ser maior que zero: %s", d.key, d.value)
+			return nil, fmt.Errorf("'%s' deve ser maior que zero: %s", d.key, d.value)
+		}
+	}
+
+	if cfg.Storage.PollInterval <= 0 {
+		log.Printf("Erro: 'storage.poll_interval' deve ser maior que zero: %s", cfg.Storage.PollInterval)
+		return nil, fmt.Errorf("'storage.poll_interval' deve ser maior que zero: %s", cfg.Storage.PollInterval)
+	}
+	if cfg.Storage.SummaryDay != "" {
+		if _, ok := weekdays[strings.ToLower(cfg.Storage.SummaryDay)]; !ok {
+			log.Printf("Erro: 'storage.summary_day' inválido: %s", cfg.Storage.SummaryDay)
+			return nil, fmt.Errorf("'storage.summary_day' inválido: %s", cfg.Storage.SummaryDay)
+		}
+	}
+	sort.Float64s(cfg.Storage.Thresholds)
+
+	if err := cfg.Filters.Zones.validate(); err != nil {
+		log.Printf("Erro: %v", err)
+		return nil, err
+	}
+	for _, camera := range cfg.Cameras {
+		if err := camera.Zones.validate(); err != nil {
+			log.Printf("Erro na câmera %s: %v", camera.Name, err)
+			return nil, fmt.Errorf("câmera %s: %w", camera.Name, err)
+		}
+	}
+
+	for _, schedule := range cfg.Filters.Schedules {
+		if err := schedule.validate(); err != nil {
+			log.Printf("Erro: %v", err)
+			return nil, err
+		}
+	}
+	for _, camera := range cfg.Cameras {
+		for _, schedule := range camera.Schedules {
+			if err := schedule.validate(); err != nil {
+				log.Printf("Erro na câmera %s: %v", camera.Name, err)
+				return nil, fmt.Errorf("câmera %s: %w", camera.Name, err)
+			}
+		}
+	}
+
+	for i, mode := range cfg.Modes {
+		if mode.Name == "" || strings.EqualFold(mode.Name, "disarm") {
+			log.Printf("Erro: nome de modo inválido: '%s'", mode.Name)
+			return nil, fmt.Errorf("nome de modo inválido: '%s'", mode.Name)
+		}
+		if cfg.GetMode(mode.Name) != &cfg.Modes[i] {
+			log.Printf("Erro: modo '%s' definido mais de uma vez", mode.Name)
+			return nil, fmt.Errorf("modo '%s' definido mais de uma vez", mode.Name)
+		}
+	}
+
 	log.Println("Configuração carregada de config.yaml")
 	return &cfg, nil
 }
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"", 24 * 60, false},
+		{"00:00", 0, false},
+		{"06:30", 6*60 + 30, false},
+		{"23:59", 23*60 + 59, false},
+		{"24:00", 24 * 60, false},
+		{"24:30", 0, true},
+		{"25:00", 0, true},
+		{"12:60", 0, true},
+		{"-1:00", 0, true},
+		{"meio-dia", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseClock(tt.value, 24*60)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClock(%q) erro = %v, esperado erro = %v", tt.value, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parseClock(%q) = %d, esperado %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZoneFilterValidate(t *testing.T) {
+	tests := []struct {
+		filter  ZoneFilter
+		wantErr bool
+	}{
+		{ZoneFilter{}, false},
+		{ZoneFilter{Match: "any", Source: "entered"}, false},
+		{ZoneFilter{Match: "all", Source: "current"}, false},
+		{ZoneFilter{Match: "al"}, true},
+		{ZoneFilter{Source: "curent"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.filter.validate(); (err != nil) != tt.wantErr {
+			t.Errorf("validate(%+v) erro = %v, esperado erro = %v", tt.filter, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScheduleMatches(t *testing.T) {
+	// 2026-10-12 é uma segunda-feira
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	night := Schedule{Labels: []string{"car"}, Days: []string{"mon"}, Start: "22:00", End: "06:00", Action: ScheduleSilent}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		label    string
+		t        time.Time
+		want     bool
+	}{
+		{"dia inteiro", Schedule{Action: ScheduleDrop}, "person", at(12, 12, 0), true},
+		{"label fora da lista", Schedule{Labels: []string{"car"}, Action: ScheduleDrop}, "person", at(12, 12, 0), false},
+		{"label sem diferenciar maiúsculas", Schedule{Labels: []string{"Car"}, Action: ScheduleDrop}, "car", at(12, 12, 0), true},
+		{"dentro da janela", Schedule{Start: "08:00", End: "18:00"}, "car", at(12, 8, 0), true},
+		{"fim da janela é exclusivo", Schedule{Start: "08:00", End: "18:00"}, "car", at(12, 18, 0), false},
+		{"fim 24:00", Schedule{Start: "20:00", End: "24:00"}, "car", at(12, 23, 59), true},
+		{"meia-noite: antes de passar do dia", night, "car", at(12, 23, 0), true},
+		{"meia-noite: madrugada conta como o dia anterior", night, "car", at(13, 2, 0), true},
+		{"meia-noite: madrugada da segunda é domingo", night, "car", at(12, 2, 0), false},
+		{"meia-noite: fora da janela", night, "car", at(12, 12, 0), false},
+		{"meia-noite: fim exclusivo", night, "car", at(13, 6, 0), false},
+		{"dia em português", Schedule{Days: []string{"seg"}}, "car", at(12, 12, 0), true},
+		{"outro dia", Schedule{Days: []string{"tue"}}, "car", at(12, 12, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Matches(tt.label, tt.t); got != tt.want {
+				t.Errorf("Matches(%q, %s) = %v, esperado %v", tt.label, tt.t.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiltersFor(t *testing.T) {
+	skip, keep := true, false
+	global := Schedule{Labels: []string{"person"}, Action: ScheduleSilent}
+	camera := Schedule{Labels: []string{"car"}, Action: ScheduleDrop}
+	cfg := &Config{
+		Filters: FilterConfig{
+			Labels:             LabelFilter{Allow: []string{"person", "car"}, Deny: []string{"cat"}},
+			Zones:              ZoneFilter{Required: []string{"rua"}, Match: "any"},
+			MinScore:           0.6,
+			MinScores:          map[string]float64{"person": 0.7},
+			SkipFalsePositives: &skip,
+			Schedules:          []Schedule{global},
+			Cooldown:           time.Minute,
+			Cooldowns:          map[string]time.Duration{"car": 5 * time.Minute},
+		},
+		Cameras: []CameraConfig{
+			{
+				Name: "Garagem",
+				FilterConfig: FilterConfig{
+					Labels:             LabelFilter{Allow: []string{"car"}, Deny: []string{"dog"}},
+					Zones:              ZoneFilter{Match: "all"},
+					MinScores:          map[string]float64{"car": 0.8},
+					SkipFalsePositives: &keep,
+					Schedules:          []Schedule{camera},
+					Cooldowns:          map[string]time.Duration{"car": 10 * time.Minute},
+				},
+			},
+		},
+	}
+
+	t.Run("câmera sem configuração usa o padrão global", func(t *testing.T) {
+		f := cfg.FiltersFor("Sala")
+		if !slices.Equal(f.Labels.Allow, []string{"person", "car"}) || !slices.Equal(f.Labels.Deny, []string{"cat"}) {
+			t.Errorf("Labels = %+v", f.Labels)
+		}
+		if f.MinScoreFor("person") != 0.7 || f.MinScoreFor("car") != 0.6 {
+			t.Errorf("MinScoreFor = %v, %v", f.MinScoreFor("person"), f.MinScoreFor("car"))
+		}
+		if f.CooldownFor("car") != 5*time.Minute || f.CooldownFor("person") != time.Minute {
+			t.Errorf("CooldownFor = %v, %v", f.CooldownFor("car"), f.CooldownFor("person"))
+		}
+		if len(f.Schedules) != 1 {
+			t.Errorf("Schedules = %+v", f.Schedules)
+		}
+	})
+
+	t.Run("câmera complementa o padrão global", func(t *testing.T) {
+		f := cfg.FiltersFor("Garagem")
+		if !slices.Equal(f.Labels.Allow, []string{"car"}) {
+			t.Errorf("Labels.Allow = %v, esperado a lista da câmera", f.Labels.Allow)
+		}
+		if !slices.Equal(f.Labels.Deny, []string{"cat", "dog"}) {
+			t.Errorf("Labels.Deny = %v, esperado as listas somadas", f.Labels.Deny)
+		}
+		if !slices.Equal(f.Zones.Required, []string{"rua"}) || f.Zones.Match != "all" {
+			t.Errorf("Zones = %+v", f.Zones)
+		}
+		if f.MinScoreFor("car") != 0.8 || f.MinScoreFor("person") != 0.7 || f.MinScoreFor("dog") != 0.6 {
+			t.Errorf("MinScoreFor = %v, %v, %v", f.MinScoreFor("car"), f.MinScoreFor("person"), f.MinScoreFor("dog"))
+		}
+		if f.SkipFalsePositives == nil || *f.SkipFalsePositives {
+			t.Errorf("SkipFalsePositives deveria ser o valor da câmera (false)")
+		}
+		if f.CooldownFor("car") != 10*time.Minute || f.CooldownFor("person") != time.Minute {
+			t.Errorf("CooldownFor = %v, %v", f.CooldownFor("car"), f.CooldownFor("person"))
+		}
+		if len(f.Schedules) != 2 || f.Schedules[0].Action != camera.Action || f.Schedules[1].Action != global.Action {
+			t.Errorf("Schedules = %+v, esperado os da câmera antes dos globais", f.Schedules)
+		}
+	})
+
+	t.Run("a mesclagem não altera o padrão global", func(t *testing.T) {
+		cfg.FiltersFor("Garagem")
+		if len(cfg.Filters.MinScores) != 1 || len(cfg.Filters.Cooldowns) != 1 || len(cfg.Filters.Labels.Deny) != 1 {
+			t.Errorf("Filters global alterado: %+v", cfg.Filters)
+		}
+	})
+}
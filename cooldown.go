@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// checkCooldown verifica se a câmera+label do evento está em cooldown.
+// Retorna false quando a notificação deve ser suprimida; nesse caso o evento é contabilizado
+// para o resumo enviado ao final da janela. Um evento suprimido continua suprimido nos próximos
+// updates, mesmo que a janela expire, pois já faz parte do resumo.
+func (h *AppHandler) checkCooldown(ctx context.Context, event FrigateEvent) (bool, error) {
+	cooldown := h.cfg.FiltersFor(event.After.Camera).CooldownFor(event.After.Label)
+	if cooldown <= 0 {
+		return true, nil
+	}
+
+	suppressed, err := h.redis.IsEventProcessed(ctx, event.After.ID, "suppressed")
+	if err != nil {
+		return false, err
+	}
+	if suppressed {
+		return false, nil
+	}
+
+	owner, err := h.redis.AcquireCooldown(ctx, event.After.Camera, event.After.Label, event.After.ID, cooldown)
+	if err != nil {
+		return false, err
+	}
+	if owner {
+		return true, nil
+	}
+
+	// Cada evento é contabilizado uma única vez
+	if err := h.redis.IncrSuppressed(ctx, event.After.Camera, event.After.Label); err != nil {
+		return false, err
+	}
+	if err := h.redis.MarkEventAsProcessed(ctx, event.After.ID, "suppressed"); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// runCooldownSummary envia periodicamente o resumo dos eventos suprimidos cujas janelas de cooldown terminaram.
+// Como os contadores ficam no Redis, os resumos pendentes sobrevivem a reinícios do bot.
+func (h *AppHandler) runCooldownSummary(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			counts, err := h.redis.PopExpiredSuppressed(ctx)
+			if err != nil {
+				log.Printf("Erro ao buscar eventos suprimidos no Redis: %v", err)
+				continue
+			}
+			for _, c := range counts {
+				if c.Count <= 0 {
+					continue
+				}
+				message := fmt.Sprintf("🔕 +%d eventos de #%s suprimidos na câmera %s", c.Count, c.Label, c.Camera)
+				if err := h.tgBot.SendMessage(ctx, message, c.Camera); err != nil {
+					log.Printf("Erro ao enviar resumo de eventos suprimidos: %v", err)
+				}
+			}
+		}
+	}
+}
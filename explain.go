@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+	"github.com/geffersonFerraz/frigate-events-telegram/rules"
+)
+
+// explainEvent faz o dry-run das regras: lê um evento JSON do Frigate e mostra
+// o resultado de cada regra avaliada e a decisão final, sem enviar nada ao Telegram
+func explainEvent(cfg *config.Config, engine *rules.Engine, path string) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao ler evento: %w", err)
+	}
+
+	var event FrigateEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("erro ao decodificar JSON do evento: %w", err)
+	}
+
+	h := &AppHandler{cfg: cfg, rules: engine}
+	ruleEvent := h.ruleEvent(event)
+	fmt.Printf("Evento: %+v\n\n", ruleEvent)
+
+	decision, traces := engine.Explain(ruleEvent)
+	for _, trace := range traces {
+		switch {
+		case trace.Err != nil:
+			fmt.Printf("⚠️  %s: %s (erro: %v)\n", trace.Rule, trace.When, trace.Err)
+		case trace.Matched:
+			fmt.Printf("✅ %s: %s\n", trace.Rule, trace.When)
+		default:
+			fmt.Printf("❌ %s: %s\n", trace.Rule, trace.When)
+		}
+	}
+	if len(traces) < engine.Len() {
+		fmt.Printf("(%d regras seguintes não foram avaliadas)\n", engine.Len()-len(traces))
+	}
+
+	fmt.Printf("\nDecisão: %s\n", decision)
+	if !decision.Matched {
+		for _, media := range []string{rules.ActionPhoto, rules.ActionClip} {
+			if _, ok, reason := h.decide(event, media); !ok {
+				fmt.Printf("Filtros (%s): descartado, %s\n", media, reason)
+			} else {
+				fmt.Printf("Filtros (%s): enviado\n", media)
+			}
+		}
+	}
+	return nil
+}
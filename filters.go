@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+	"github.com/geffersonFerraz/frigate-events-telegram/rules"
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+// containsLabel verifica se a label está presente na lista (sem diferenciar maiúsculas/minúsculas)
+func containsLabel(list []string, label string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterEvent verifica se o evento deve ser enviado ao Telegram de acordo com os filtros
+// globais e da câmera. Retorna false e o motivo quando o evento deve ser descartado.
+func (h *AppHandler) filterEvent(event FrigateEvent) (bool, string) {
+	filters := h.cfg.FiltersFor(event.After.Camera)
+
+	if ok, reason := checkLabelFilter(filters.Labels, "label", event.After.Label); !ok {
+		return false, reason
+	}
+	if ok, reason := checkLabelFilter(filters.SubLabels, "sub_label", event.After.SubLabel.Name); !ok {
+		return false, reason
+	}
+	if ok, reason := checkLabelFilter(filters.Plates, "placa", event.After.RecognizedLicensePlate.Name); !ok {
+		return false, reason
+	}
+
+	if ok, reason := checkZones(filters.Zones, event); !ok {
+		return false, reason
+	}
+
+	if filters.SkipFalsePositives != nil && *filters.SkipFalsePositives && event.After.FalsePositive {
+		return false, "evento marcado como falso positivo"
+	}
+	if filters.SkipStationary != nil && *filters.SkipStationary && event.After.Stationary {
+		return false, "objeto parado (stationary)"
+	}
+
+	// Eventos sem score (ex.: review items) não são filtrados pelo score mínimo
+	if minScore := filters.MinScoreFor(event.After.Label); minScore > 0 && eventScore(event) > 0 && eventScore(event) < minScore {
+		return false, fmt.Sprintf("score %.0f%% abaixo do mínimo de %.0f%%", eventScore(event)*100, minScore*100)
+	}
+
+	return true, ""
+}
+
+// eventStart retorna o início do evento com o ajuste de fuso configurado, usado tanto pelas
+// regras quanto pelos schedules. Sem start_time, usa o horário atual.
+func (h *AppHandler) eventStart(event FrigateEvent) time.Time {
+	if event.After.StartTime <= 0 {
+		return h.localTime(time.Now())
+	}
+	return h.localTime(time.Unix(int64(event.After.StartTime), 0))
+}
+
+// ruleEvent converte o evento do Frigate nos campos disponíveis para as regras.
+// Os campos de horário usam o início do evento com o ajuste de fuso configurado.
+func (h *AppHandler) ruleEvent(event FrigateEvent) rules.Event {
+	start := h.eventStart(event)
+	return rules.Event{
+		Type:     event.Type,
+		Camera:   event.After.Camera,
+		Label:    event.After.Label,
+		SubLabel: event.After.SubLabel.Name,
+		Plate:    event.After.RecognizedLicensePlate.Name,
+		Zones:    eventZones(event),
+		Score:    eventScore(event),
+		Hour:     start.Hour(),
+		Minute:   start.Minute(),
+		Time:     start.Format("15:04"),
+		Weekday:  strings.ToLower(start.Weekday().String()[:3]),
+	}
+}
+
+// decide define se e como o evento deve ser enviado para o tipo de mídia informado
+// (rules.ActionPhoto ou rules.ActionClip). Quando uma regra corresponde ao evento, ela define o envio;
+// caso contrário são aplicados os filtros e schedules. Retorna false e o motivo quando nada deve ser enviado.
+func (h *AppHandler) decide(event FrigateEvent, media string) (telegram_handler.SendOptions, bool, string) {
+	decision := h.rules.Evaluate(h.ruleEvent(event))
+	if decision.Matched {
+		if decision.Drop {
+			return telegram_handler.SendOptions{}, false, fmt.Sprintf("regra '%s' descarta o evento", decision.Rule)
+		}
+		if (media == rules.ActionPhoto && !decision.Photo) || (media == rules.ActionClip && !decision.Clip) {
+			return telegram_handler.SendOptions{}, false, fmt.Sprintf("regra '%s' não envia %s", decision.Rule, media)
+		}
+		return telegram_handler.SendOptions{
+			Silent:   decision.Silent,
+			ChatID:   decision.ChatID,
+			ThreadID: decision.ThreadID,
+		}, true, ""
+	}
+
+	if ok, reason := h.filterEvent(event); !ok {
+		return telegram_handler.SendOptions{}, false, reason
+	}
+
+	opts, ok := h.scheduleOptions(event)
+	if !ok {
+		return telegram_handler.SendOptions{}, false, "fora do horário de notificação"
+	}
+	return opts, true, ""
+}
+
+// scheduleOptions avalia os schedules da câmera para o horário de início do evento.
+// Retorna false quando o evento não deve ser enviado.
+func (h *AppHandler) scheduleOptions(event FrigateEvent) (telegram_handler.SendOptions, bool) {
+	filters := h.cfg.FiltersFor(event.After.Camera)
+
+	switch filters.ScheduleAction(event.After.Label, h.eventStart(event)) {
+	case config.ScheduleDrop:
+		return telegram_handler.SendOptions{}, false
+	case config.ScheduleSilent:
+		return telegram_handler.SendOptions{Silent: true}, true
+	}
+	return telegram_handler.SendOptions{}, true
+}
+
+// checkLabelFilter aplica as listas allow/deny a um valor do evento (label, sub_label, placa)
+func checkLabelFilter(filter config.LabelFilter, field string, value string) (bool, string) {
+	if value != "" && containsLabel(filter.Deny, value) {
+		return false, fmt.Sprintf("%s '%s' está na lista deny", field, value)
+	}
+	if len(filter.Allow) > 0 && !containsLabel(filter.Allow, value) {
+		if value == "" {
+			return false, fmt.Sprintf("evento sem %s e a lista allow está definida", field)
+		}
+		return false, fmt.Sprintf("%s '%s' não está na lista allow", field, value)
+	}
+	return true, ""
+}
+
+// eventScore retorna o melhor score do objeto (top_score, ou score quando top_score não existe)
+func eventScore(event FrigateEvent) float64 {
+	if event.After.TopScore > 0 {
+		return event.After.TopScore
+	}
+	return event.After.Score
+}
+
+// eventZones retorna as zonas em que o objeto do evento entrou (ou está, caso não haja registro de entrada)
+func eventZones(event FrigateEvent) []string {
+	if len(event.After.EnteredZones) > 0 {
+		return event.After.EnteredZones
+	}
+	return event.After.CurrentZones
+}
+
+// checkZones verifica se o objeto está nas zonas exigidas para a câmera
+func checkZones(filter config.ZoneFilter, event FrigateEvent) (bool, string) {
+	if len(filter.Required) == 0 {
+		return true, ""
+	}
+
+	zones := event.After.EnteredZones
+	// No fim do evento o Frigate limpa current_zones, então usamos as zonas de entrada
+	if filter.Source == "current" && event.Type != "end" {
+		zones = event.After.CurrentZones
+	}
+
+	matched := 0
+	for _, required := range filter.Required {
+		if containsLabel(zones, required) {
+			matched++
+		}
+	}
+
+	if filter.Match == "all" {
+		if matched < len(filter.Required) {
+			return false, fmt.Sprintf("objeto não está em todas as zonas exigidas %v (zonas: %v)", filter.Required, zones)
+		}
+		return true, ""
+	}
+
+	if matched == 0 {
+		return false, fmt.Sprintf("objeto não está em nenhuma das zonas exigidas %v (zonas: %v)", filter.Required, zones)
+	}
+	return true, ""
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+	"github.com/geffersonFerraz/frigate-events-telegram/rules"
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+func TestDecide(t *testing.T) {
+	cfg := &config.Config{
+		Filters: config.FilterConfig{
+			Labels: config.LabelFilter{Deny: []string{"cat"}},
+		},
+		Cameras: []config.CameraConfig{
+			{
+				Name: "Garagem",
+				FilterConfig: config.FilterConfig{
+					Schedules: []config.Schedule{
+						{Labels: []string{"car"}, Start: "22:00", End: "06:00", Action: config.ScheduleSilent},
+						{Labels: []string{"car"}, Action: config.ScheduleDrop},
+					},
+				},
+			},
+		},
+	}
+	engine, err := rules.NewEngine([]config.Rule{
+		{Name: "gato-portao", When: `label == "cat" && camera == "Portao"`, Actions: []string{rules.ActionPhoto}, ThreadID: 7},
+		{Name: "sem-cachorro", When: `label == "dog"`, Actions: []string{rules.ActionDrop}},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine() erro = %v", err)
+	}
+	h := &AppHandler{cfg: cfg, rules: engine}
+
+	at := func(hour int) float64 {
+		return float64(time.Date(2026, 10, 14, hour, 30, 0, 0, time.Local).Unix())
+	}
+	event := func(camera, label string, start float64) FrigateEvent {
+		var e FrigateEvent
+		e.Type = "new"
+		e.After.ID = "1760000000.123456-abc123"
+		e.After.Camera = camera
+		e.After.Label = label
+		e.After.StartTime = start
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		event    FrigateEvent
+		media    string
+		wantOK   bool
+		wantOpts telegram_handler.SendOptions
+	}{
+		{"regra tem precedência sobre o deny", event("Portao", "cat", at(12)), rules.ActionPhoto, true, telegram_handler.SendOptions{ThreadID: 7}},
+		{"regra sem clipe", event("Portao", "cat", at(12)), rules.ActionClip, false, telegram_handler.SendOptions{}},
+		{"regra drop", event("Portao", "dog", at(12)), rules.ActionPhoto, false, telegram_handler.SendOptions{}},
+		{"sem regra, filtro deny", event("Sala", "cat", at(12)), rules.ActionPhoto, false, telegram_handler.SendOptions{}},
+		{"sem regra nem filtro", event("Sala", "person", at(12)), rules.ActionPhoto, true, telegram_handler.SendOptions{}},
+		{"schedule noturno pelo início do evento", event("Garagem", "car", at(23)), rules.ActionPhoto, true, telegram_handler.SendOptions{Silent: true}},
+		{"schedule diurno pelo início do evento", event("Garagem", "car", at(12)), rules.ActionPhoto, false, telegram_handler.SendOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, ok, reason := h.decide(tt.event, tt.media)
+			if ok != tt.wantOK {
+				t.Fatalf("decide() = %v (%s), esperado %v", ok, reason, tt.wantOK)
+			}
+			if opts != tt.wantOpts {
+				t.Errorf("decide() opções = %+v, esperado %+v", opts, tt.wantOpts)
+			}
+		})
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
 )
 
 type Frigate struct {
@@ -17,7 +21,72 @@ func NewFrigate(url string) *Frigate {
 	return &Frigate{URL: url}
 }
 func (f *Frigate) GetSnapshot(ctx context.Context, camera string) ([]byte, error) {
-	resp, err := http.Get(fmt.Sprintf("%s/api/%s/latest.jpg", f.URL, camera))
+	return f.get(ctx, fmt.Sprintf("/api/%s/latest.jpg", camera))
+}
+
+type EventResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	EventID string `json:"event_id"`
+}
+
+// ManualEventLabel é a label (e sub_label) dos eventos manuais criados pelo bot
+const ManualEventLabel = "telegram"
+
+// CreateEvent cria um evento manual de gravação na câmera e retorna o ID do evento criado
+func (f *Frigate) CreateEvent(ctx context.Context, camera string, durationSeconds int) (string, error) {
+	payload := map[string]any{
+		"duration":          durationSeconds,
+		"source_type":       "telegram",
+		"sub_label":         ManualEventLabel,
+		"score":             0,
+		"include_recording": true,
+		"draw":              map[string]any{},
+	}
+	body, err := f.request(ctx, http.MethodPost, fmt.Sprintf("/api/events/%s/%s/create", camera, ManualEventLabel), payload)
+	if err != nil {
+		return "", err
+	}
+
+	var data EventResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("erro ao decodificar resposta do evento: %w", err)
+	}
+	if !data.Success {
+		return "", fmt.Errorf("frigate recusou o evento: %s", data.Message)
+	}
+	if data.EventID == "" {
+		return "", fmt.Errorf("frigate não retornou o ID do evento")
+	}
+	return data.EventID, nil
+}
+
+// get faz um GET na API do Frigate e retorna o corpo da resposta, validando o status
+func (f *Frigate) get(ctx context.Context, path string) ([]byte, error) {
+	return f.request(ctx, http.MethodGet, path, nil)
+}
+
+// request faz uma requisição na API do Frigate, com um corpo JSON opcional, e retorna o corpo da resposta,
+// validando o status
+func (f *Frigate) request(ctx context.Context, method, path string, payload any) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao serializar requisição: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", strings.TrimSuffix(f.URL, "/"), path), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -27,33 +96,310 @@ func (f *Frigate) GetSnapshot(ctx context.Context, camera string) ([]byte, error
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code %d: %s", resp.StatusCode, string(body))
+	}
 	return body, nil
 }
 
-type EventResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	EventID string `json:"event_id"`
+// GetReviewPreview retorna o vídeo de prévia (mp4) de um review item
+func (f *Frigate) GetReviewPreview(ctx context.Context, reviewID string) ([]byte, error) {
+	return f.get(ctx, fmt.Sprintf("/api/review/%s/preview?format=mp4", reviewID))
 }
 
-func (f *Frigate) CreateEvent(ctx context.Context, camera string, durationSeconds int) (string, error) {
-	body := fmt.Sprintf(`{"duration": %d, "source_type": "telegram", "sub_label": "telegram", "score": 0, "include_recording": true, "draw": {}}`, durationSeconds)
-	resp, err := http.Post(fmt.Sprintf("%s/api/events/%s/telegram/create", f.URL, camera), "application/json", bytes.NewBuffer([]byte(body)))
+// CameraStats representa as estatísticas de uma câmera em /api/stats
+type CameraStats struct {
+	CameraFPS    float64 `json:"camera_fps"`
+	DetectionFPS float64 `json:"detection_fps"`
+	ProcessFPS   float64 `json:"process_fps"`
+	SkippedFPS   float64 `json:"skipped_fps"`
+	PID          int     `json:"pid"`
+}
+
+// DetectorStats representa as estatísticas de um detector em /api/stats
+type DetectorStats struct {
+	InferenceSpeed float64 `json:"inference_speed"` // em milissegundos
+	PID            int     `json:"pid"`
+}
+
+// UsageStats representa o uso de CPU/GPU e memória (o Frigate envia os valores como texto)
+type UsageStats struct {
+	CPU string `json:"cpu"`
+	GPU string `json:"gpu"`
+	Mem string `json:"mem"`
+}
+
+// StorageStats representa o uso de um ponto de montagem, em MB
+type StorageStats struct {
+	Total     float64 `json:"total"`
+	Used      float64 `json:"used"`
+	Free      float64 `json:"free"`
+	MountType string  `json:"mount_type"`
+}
+
+// ServiceStats representa as informações do serviço do Frigate
+type ServiceStats struct {
+	Uptime  int64                   `json:"uptime"`
+	Version string                  `json:"version"`
+	Storage map[string]StorageStats `json:"storage"`
+}
+
+// Stats representa a resposta de /api/stats
+type Stats struct {
+	Cameras      map[string]CameraStats   `json:"cameras"`
+	Detectors    map[string]DetectorStats `json:"detectors"`
+	CPUUsages    map[string]UsageStats    `json:"cpu_usages"`
+	GPUUsages    map[string]UsageStats    `json:"gpu_usages"`
+	DetectionFPS float64                  `json:"detection_fps"`
+	Service      ServiceStats             `json:"service"`
+}
+
+// GetStats retorna as estatísticas do sistema do Frigate
+func (f *Frigate) GetStats(ctx context.Context) (*Stats, error) {
+	body, err := f.get(ctx, "/api/stats")
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var stats Stats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar estatísticas: %w", err)
+	}
+	return &stats, nil
+}
+
+// CameraStorage representa o uso de armazenamento das gravações de uma câmera
+type CameraStorage struct {
+	Usage        float64 `json:"usage"`         // em MB
+	UsagePercent float64 `json:"usage_percent"` // percentual do total usado pelas gravações
+	Bandwidth    float64 `json:"bandwidth"`     // em MB/h
+}
+
+// GetRecordingsStorage retorna o uso de armazenamento das gravações por câmera
+func (f *Frigate) GetRecordingsStorage(ctx context.Context) (map[string]CameraStorage, error) {
+	body, err := f.get(ctx, "/api/recordings/storage")
+	if err != nil {
+		return nil, err
+	}
+
+	var storage map[string]CameraStorage
+	if err := json.Unmarshal(body, &storage); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar armazenamento: %w", err)
+	}
+	return storage, nil
+}
+
+// Event representa um evento (objeto rastreado) retornado por /api/events
+type Event struct {
+	ID          string   `json:"id"`
+	Camera      string   `json:"camera"`
+	Label       string   `json:"label"`
+	SubLabel    string   `json:"sub_label"`
+	StartTime   float64  `json:"start_time"`
+	EndTime     float64  `json:"end_time"` // 0 enquanto o evento está em andamento
+	HasClip     bool     `json:"has_clip"`
+	HasSnapshot bool     `json:"has_snapshot"`
+	Zones       []string `json:"zones"`
+	Data        struct {
+		TopScore float64 `json:"top_score"`
+		Score    float64 `json:"score"`
+	} `json:"data"`
+}
+
+// ShortID retorna o ID curto de um evento, usado nas hashtags das legendas
+// (ex.: "1718000000.123456-abc123" -> "123456abc123")
+func ShortID(id string) string {
+	id = strings.ReplaceAll(id, "-", "")
+	result := strings.Split(id, ".")
+	if len(result) > 1 {
+		return result[1]
+	}
+	return id
+}
+
+// EventsQuery define os filtros da consulta de eventos
+type EventsQuery struct {
+	Camera string  // vazio = todas as câmeras
+	Label  string  // vazio = todas as labels
+	After  float64 // timestamp inicial (0 = sem limite)
+	Before float64 // timestamp final (0 = sem limite)
+	Limit  int     // quantidade máxima de eventos (0 = padrão do Frigate)
+}
+
+// GetEvents consulta os eventos do Frigate, do mais recente para o mais antigo
+func (f *Frigate) GetEvents(ctx context.Context, query EventsQuery) ([]Event, error) {
+	params := url.Values{}
+	if query.Camera != "" {
+		params.Set("cameras", query.Camera)
+	}
+	if query.Label != "" {
+		params.Set("labels", query.Label)
+	}
+	if query.After > 0 {
+		params.Set("after", strconv.FormatFloat(query.After, 'f', -1, 64))
+	}
+	if query.Before > 0 {
+		params.Set("before", strconv.FormatFloat(query.Before, 'f', -1, 64))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	body, err := f.get(ctx, "/api/events?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar eventos: %w", err)
+	}
+	return events, nil
+}
+
+// GetEvent retorna um evento pelo ID
+func (f *Frigate) GetEvent(ctx context.Context, eventID string) (*Event, error) {
+	body, err := f.get(ctx, fmt.Sprintf("/api/events/%s", eventID))
+	if err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar evento: %w", err)
+	}
+	return &event, nil
+}
+
+// GetEventSnapshot retorna o snapshot de um evento
+func (f *Frigate) GetEventSnapshot(ctx context.Context, eventID string) ([]byte, error) {
+	return f.get(ctx, fmt.Sprintf("/api/events/%s/snapshot.jpg", eventID))
+}
+
+// GetEventClip retorna o clipe (mp4) de um evento
+func (f *Frigate) GetEventClip(ctx context.Context, eventID string) ([]byte, error) {
+	return f.get(ctx, fmt.Sprintf("/api/events/%s/clip.mp4", eventID))
+}
+
+// MarkFalsePositive marca um evento como falso positivo no Frigate
+func (f *Frigate) MarkFalsePositive(ctx context.Context, eventID string) error {
+	if _, err := f.request(ctx, http.MethodPut, fmt.Sprintf("/api/events/%s/false_positive", eventID), nil); err != nil {
+		return fmt.Errorf("erro ao marcar falso positivo: %w", err)
+	}
+	return nil
+}
+
+// RetainEvent marca um evento para ser mantido indefinidamente pelo Frigate
+func (f *Frigate) RetainEvent(ctx context.Context, eventID string) error {
+	if _, err := f.request(ctx, http.MethodPost, fmt.Sprintf("/api/events/%s/retain", eventID), nil); err != nil {
+		return fmt.Errorf("erro ao reter evento: %w", err)
+	}
+	return nil
+}
+
+// Export representa uma exportação de gravação do Frigate (/api/exports)
+type Export struct {
+	ID         string  `json:"id"`
+	Camera     string  `json:"camera"`
+	Name       string  `json:"name"`
+	Date       float64 `json:"date"`
+	VideoPath  string  `json:"video_path"`
+	InProgress bool    `json:"in_progress"`
+}
+
+// StartExport inicia a exportação da gravação de uma câmera no intervalo informado (timestamps).
+// Retorna o ID da exportação, ou "" nas versões do Frigate que não o informam (nesse caso use FindExport).
+func (f *Frigate) StartExport(ctx context.Context, camera string, start, end int64, name string) (string, error) {
+	payload := map[string]string{"playback": "realtime", "name": name}
+	body, err := f.request(ctx, http.MethodPost, fmt.Sprintf("/api/export/%s/start/%d/end/%d", camera, start, end), payload)
+	if err != nil {
+		return "", fmt.Errorf("erro ao iniciar exportação: %w", err)
+	}
+
+	var data struct {
+		Success  bool   `json:"success"`
+		Message  string `json:"message"`
+		ExportID string `json:"export_id"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("erro ao decodificar resposta da exportação: %w", err)
+	}
+	if !data.Success {
+		return "", fmt.Errorf("erro ao iniciar exportação: %s", data.Message)
+	}
+	return data.ExportID, nil
+}
+
+// GetExport retorna uma exportação pelo ID
+func (f *Frigate) GetExport(ctx context.Context, exportID string) (*Export, error) {
+	body, err := f.get(ctx, fmt.Sprintf("/api/exports/%s", exportID))
+	if err != nil {
+		return nil, err
+	}
+
+	var export Export
+	if err := json.Unmarshal(body, &export); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar exportação: %w", err)
+	}
+	return &export, nil
+}
+
+// FindExport procura uma exportação pelo nome, ou retorna nil se não existir
+func (f *Frigate) FindExport(ctx context.Context, name string) (*Export, error) {
+	body, err := f.get(ctx, "/api/exports")
+	if err != nil {
+		return nil, err
+	}
+
+	var exports []Export
+	if err := json.Unmarshal(body, &exports); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar exportações: %w", err)
+	}
+	for i := range exports {
+		if exports[i].Name == name {
+			return &exports[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteExport remove uma exportação (e o vídeo dela) do disco do Frigate
+func (f *Frigate) DeleteExport(ctx context.Context, exportID string) error {
+	if _, err := f.request(ctx, http.MethodDelete, fmt.Sprintf("/api/export/%s", exportID), nil); err != nil {
+		return fmt.Errorf("erro ao remover exportação: %w", err)
+	}
+	return nil
+}
+
+// DownloadExport baixa o vídeo de uma exportação concluída, lendo no máximo maxSize bytes.
+// Retorna true quando o vídeo é maior que o limite e foi cortado.
+func (f *Frigate) DownloadExport(ctx context.Context, export Export, maxSize int64) ([]byte, bool, error) {
+	if export.VideoPath == "" {
+		return nil, false, fmt.Errorf("exportação %s sem vídeo", export.ID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/exports/%s", strings.TrimSuffix(f.URL, "/"), url.PathEscape(path.Base(export.VideoPath))), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, err
 	}
 	defer resp.Body.Close()
 
-	// response
-	// {
-	// 	"success": true,
-	// 	"message": "string",
-	// 	"event_id": "string"
-	//   }
-	var data EventResponse
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, false, fmt.Errorf("status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Lê um byte além do limite para saber se o vídeo foi cortado, mesmo sem Content-Length
+	video, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
-	return data.EventID, nil
+	if int64(len(video)) > maxSize {
+		return video[:maxSize], true, nil
+	}
+	return video, false, nil
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+// TrackedObjectUpdate representa uma mensagem do tópico frigate/tracked_object_update
+type TrackedObjectUpdate struct {
+	Type        string `json:"type"` // "description" para descrições da GenAI
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// withDescription adiciona a descrição da GenAI ao final da legenda
+func withDescription(caption string, description string) string {
+	if description == "" {
+		return caption
+	}
+	return fmt.Sprintf("%s\n\n🤖 %s", caption, description)
+}
+
+// matchesKeywords verifica se a descrição contém alguma das palavras configuradas
+func matchesKeywords(description string, keywords []string) bool {
+	if len(keywords) == 0 {
+		return true
+	}
+	description = strings.ToLower(description)
+	for _, keyword := range keywords {
+		if strings.Contains(description, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleTrackedObjectUpdate publica a descrição da GenAI de um evento já notificado,
+// editando a legenda da foto ou respondendo a ela
+func (h *AppHandler) handleTrackedObjectUpdate(client mqtt.Client, msg mqtt.Message) {
+	var update TrackedObjectUpdate
+	if err := json.Unmarshal(msg.Payload(), &update); err != nil {
+		log.Printf("Erro ao decodificar JSON do tracked_object_update: %v", err)
+		return
+	}
+	if update.Type != "description" || update.Description == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	notification, err := h.redis.GetEventNotification(ctx, update.ID)
+	if err != nil {
+		log.Printf("Erro ao buscar notificação do evento %s: %v", update.ID, err)
+		return
+	}
+	if notification == nil {
+		log.Printf("Descrição recebida para o evento %s, que não foi notificado, ignorando.", update.ID)
+		return
+	}
+	if !matchesKeywords(update.Description, h.cfg.GenAI.Keywords) {
+		log.Printf("Descrição do evento %s não contém as palavras-chave configuradas, ignorando.", update.ID)
+		return
+	}
+
+	sent := notificationMessage(update.ID, notification)
+
+	// Em álbuns a legenda é compartilhada, então a descrição é enviada como resposta
+	if h.cfg.GenAI.Mode == config.GenAIModeEdit && !notification.Album {
+		caption := withDescription(notification.Caption, update.Description)
+		if err := h.tgBot.EditCaption(ctx, sent, caption); err != nil {
+			log.Printf("Erro ao adicionar descrição do evento %s na legenda: %v", update.ID, err)
+			return
+		}
+	} else {
+		opts := telegram_handler.SendOptions{
+			Silent:   true,
+			ReplyTo:  notification.MessageID,
+			ChatID:   notification.ChatID,
+			ThreadID: int64(notification.ThreadID),
+		}
+		if _, err := h.tgBot.SendText(ctx, "🤖 "+update.Description, "", opts); err != nil {
+			log.Printf("Erro ao responder com a descrição do evento %s: %v", update.ID, err)
+			return
+		}
+	}
+	log.Printf("Descrição da GenAI do evento %s publicada no Telegram.", update.ID)
+
+	notification.Description = update.Description
+	if err := h.redis.SaveEventNotification(ctx, update.ID, *notification); err != nil {
+		log.Printf("Erro ao salvar notificação do evento %s no Redis: %v", update.ID, err)
+	}
+}
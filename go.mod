@@ -4,6 +4,7 @@ go 1.24.2
 
 require (
 	github.com/eclipse/paho.mqtt.golang v1.5.0
+	github.com/expr-lang/expr v1.17.8
 	github.com/go-telegram/bot v1.14.1
 	github.com/google/uuid v1.6.0
 	github.com/redis/go-redis/v9 v9.7.3
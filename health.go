@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+// healthMonitor acompanha a disponibilidade do Frigate (tópico frigate/available e /api/stats)
+// e das câmeras (fps zerado), alertando a thread General nas quedas e recuperações
+type healthMonitor struct {
+	h *AppHandler
+
+	mu            sync.Mutex
+	frigateDown   time.Time // início da indisponibilidade do Frigate (zero = online)
+	frigateAlert  bool      // alerta de queda do Frigate já enviado
+	cameraDown    map[string]time.Time
+	cameraAlerted map[string]bool
+}
+
+// newHealthMonitor cria um novo monitor de disponibilidade
+func newHealthMonitor(h *AppHandler) *healthMonitor {
+	return &healthMonitor{
+		h:             h,
+		cameraDown:    make(map[string]time.Time),
+		cameraAlerted: make(map[string]bool),
+	}
+}
+
+// notify envia um alerta de disponibilidade para a thread General
+func (m *healthMonitor) notify(text string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := m.h.tgBot.SendMessage(ctx, text, "General"); err != nil {
+		log.Printf("Erro ao enviar alerta de disponibilidade: %v", err)
+	}
+}
+
+// handleAvailability processa o tópico frigate/available ("online" ou "offline")
+func (m *healthMonitor) handleAvailability(client mqtt.Client, msg mqtt.Message) {
+	switch string(msg.Payload()) {
+	case "offline":
+		m.frigateOffline(true)
+	case "online":
+		m.frigateOnline()
+	}
+}
+
+// frigateOffline registra a indisponibilidade do Frigate. Quando informada pelo MQTT o alerta é imediato;
+// falhas na API só alertam após o tempo configurado
+func (m *healthMonitor) frigateOffline(immediate bool) {
+	m.mu.Lock()
+	if m.frigateDown.IsZero() {
+		m.frigateDown = time.Now()
+	}
+	shouldAlert := !m.frigateAlert && (immediate || time.Since(m.frigateDown) >= m.h.cfg.Health.FrigateOfflineAfter)
+	if shouldAlert {
+		m.frigateAlert = true
+	}
+	m.mu.Unlock()
+
+	if shouldAlert {
+		log.Println("Frigate indisponível, enviando alerta.")
+		m.notify("🔴 Frigate está offline!")
+	}
+}
+
+// frigateOnline registra a volta do Frigate e envia a recuperação com o tempo de indisponibilidade
+func (m *healthMonitor) frigateOnline() {
+	m.mu.Lock()
+	down := m.frigateDown
+	alerted := m.frigateAlert
+	m.frigateDown = time.Time{}
+	m.frigateAlert = false
+	m.mu.Unlock()
+
+	if alerted {
+		log.Println("Frigate voltou a ficar disponível.")
+		m.notify(fmt.Sprintf("🟢 Frigate está online novamente (fora do ar por %s)", telegram_handler.FormatDuration(time.Since(down))))
+	}
+}
+
+// checkCameras verifica as câmeras com fps zerado e envia alertas de queda e recuperação
+func (m *healthMonitor) checkCameras(cameras map[string]float64) {
+	names := make([]string, 0, len(cameras))
+	for name := range cameras {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var alerts []string
+	m.mu.Lock()
+	for _, name := range names {
+		if cameras[name] > 0 {
+			if m.cameraAlerted[name] {
+				alerts = append(alerts, fmt.Sprintf("🟢 Câmera %s voltou a enviar imagens (fora do ar por %s)", name, telegram_handler.FormatDuration(time.Since(m.cameraDown[name]))))
+			}
+			delete(m.cameraDown, name)
+			delete(m.cameraAlerted, name)
+			continue
+		}
+
+		if _, ok := m.cameraDown[name]; !ok {
+			m.cameraDown[name] = time.Now()
+		}
+		if !m.cameraAlerted[name] && time.Since(m.cameraDown[name]) >= m.h.cfg.Health.CameraOfflineAfter {
+			m.cameraAlerted[name] = true
+			alerts = append(alerts, fmt.Sprintf("🔴 Câmera %s sem imagens (0 fps) há %s", name, telegram_handler.FormatDuration(time.Since(m.cameraDown[name]))))
+		}
+	}
+	m.mu.Unlock()
+
+	for _, alert := range alerts {
+		log.Println(alert)
+		m.notify(alert)
+	}
+}
+
+// run consulta periodicamente /api/stats do Frigate
+func (m *healthMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.h.cfg.Health.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			statsCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			stats, err := m.h.frigate.GetStats(statsCtx)
+			cancel()
+			if err != nil {
+				log.Printf("Erro ao consultar estatísticas do Frigate: %v", err)
+				m.frigateOffline(false)
+				continue
+			}
+			m.frigateOnline()
+
+			cameras := make(map[string]float64, len(stats.Cameras))
+			for name, camera := range stats.Cameras {
+				cameras[name] = camera.CameraFPS
+			}
+			m.checkCameras(cameras)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	"unicode"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	// Removido tgbot de propósito pois não é usado diretamente em main agora
@@ -20,28 +22,62 @@ import (
 	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
 	"github.com/geffersonFerraz/frigate-events-telegram/mqtt_handler"
 	"github.com/geffersonFerraz/frigate-events-telegram/redis_handler"
+	"github.com/geffersonFerraz/frigate-events-telegram/rules"
 	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
 )
 
+// ScoredLabel representa um valor identificado pelo Frigate junto com o seu score.
+// O Frigate envia sub_label e recognized_license_plate como string, como [nome, score] ou null,
+// dependendo da versão.
+type ScoredLabel struct {
+	Name  string
+	Score float64
+}
+
+// UnmarshalJSON aceita os formatos string, [nome, score] e null
+func (l *ScoredLabel) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		l.Name = name
+		return nil
+	}
+
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("formato inválido para label: %s", string(data))
+	}
+	if len(values) > 0 {
+		l.Name, _ = values[0].(string)
+	}
+	if len(values) > 1 {
+		l.Score, _ = values[1].(float64)
+	}
+	return nil
+}
+
+// FrigateEventData representa os dados de um objeto rastreado pelo Frigate (antes/depois)
+type FrigateEventData struct {
+	ID                     string      `json:"id"`
+	Label                  string      `json:"label"`
+	Camera                 string      `json:"camera"`
+	StartTime              float64     `json:"start_time"`
+	HasSnapshot            bool        `json:"has_snapshot"`
+	HasClip                bool        `json:"has_clip"`
+	Score                  float64     `json:"score"`
+	TopScore               float64     `json:"top_score"`
+	FalsePositive          bool        `json:"false_positive"`
+	Stationary             bool        `json:"stationary"`
+	SubLabel               ScoredLabel `json:"sub_label"`
+	RecognizedLicensePlate ScoredLabel `json:"recognized_license_plate"`
+	CurrentZones           []string    `json:"current_zones"`
+	EnteredZones           []string    `json:"entered_zones"`
+}
+
 // FrigateEvent representa a estrutura básica de um evento do Frigate (pode precisar de mais campos)
 type FrigateEvent struct {
-	Before struct {
-		ID          string  `json:"id"`
-		Label       string  `json:"label"`
-		Camera      string  `json:"camera"`
-		StartTime   float64 `json:"start_time"`
-		HasSnapshot bool    `json:"has_snapshot"`
-		HasClip     bool    `json:"has_clip"`
-	} `json:"before"`
-	After struct {
-		ID          string  `json:"id"`
-		Label       string  `json:"label"`
-		Camera      string  `json:"camera"`
-		StartTime   float64 `json:"start_time"`
-		HasSnapshot bool    `json:"has_snapshot"`
-		HasClip     bool    `json:"has_clip"`
-	} `json:"after"`
-	Type string `json:"type"` // "new", "update", "end"
+	Before FrigateEventData `json:"before"`
+	After  FrigateEventData `json:"after"`
+	Type   string           `json:"type"` // "new", "update", "end"
 }
 
 // AppHandler contém as dependências necessárias para o handler MQTT
@@ -50,16 +86,25 @@ type AppHandler struct {
 	cfg        *config.Config
 	httpClient *http.Client // Para buscar a imagem
 	redis      *redis_handler.RedisHandler
+	albums     *albumBatcher // nil quando o agrupamento em álbuns está desativado
+	rules      *rules.Engine
+	frigate    *frigate.Frigate
 }
 
 // newAppHandler cria uma nova instância do AppHandler
-func newAppHandler(bot telegram_handler.Telegram, cfg *config.Config, redis *redis_handler.RedisHandler) *AppHandler {
-	return &AppHandler{
+func newAppHandler(bot telegram_handler.Telegram, cfg *config.Config, redis *redis_handler.RedisHandler, engine *rules.Engine, frigate *frigate.Frigate) *AppHandler {
+	h := &AppHandler{
 		tgBot:      bot,
 		cfg:        cfg,
 		httpClient: &http.Client{Timeout: 10 * time.Second}, // Timeout de 10s para buscar imagem
 		redis:      redis,
+		rules:      engine,
+		frigate:    frigate,
+	}
+	if cfg.AlbumWindow > 0 {
+		h.albums = newAlbumBatcher(bot, cfg.AlbumWindow)
 	}
+	return h
 }
 
 // downloadVideo tenta baixar o vídeo do Frigate, com retry se necessário
@@ -110,8 +155,36 @@ func (h *AppHandler) downloadVideo(ctx context.Context, clipURL string, maxRetri
 	return nil, fmt.Errorf("falha após %d tentativas: %v", maxRetries, lastErr)
 }
 
+// fetchSnapshot baixa o snapshot de um evento do Frigate
+func (h *AppHandler) fetchSnapshot(ctx context.Context, eventID string) ([]byte, error) {
+	// Construir URL do snapshot
+	snapshotURL := fmt.Sprintf("%s/api/events/%s/snapshot.jpg", strings.TrimSuffix(h.cfg.FrigateURL, "/"), eventID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", snapshotURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar request para snapshot %s: %w", snapshotURL, err)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar snapshot %s: %w", snapshotURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("erro ao buscar snapshot %s: status %d: %s", snapshotURL, resp.StatusCode, string(bodyBytes))
+	}
+
+	imgBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler bytes do snapshot %s: %w", snapshotURL, err)
+	}
+	return imgBytes, nil
+}
+
 // processVideoEvent processa o download e envio do vídeo em uma goroutine separada
-func (h *AppHandler) processVideoEvent(ctx context.Context, event FrigateEvent, clipURL string) {
+func (h *AppHandler) processVideoEvent(ctx context.Context, event FrigateEvent, clipURL string, opts telegram_handler.SendOptions) {
 	// Criar um contexto com timeout para todo o processo
 	videoCtx, videoCancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer videoCancel()
@@ -127,22 +200,13 @@ func (h *AppHandler) processVideoEvent(ctx context.Context, event FrigateEvent,
 			return
 		}
 
-		// if videoBytes > 49mb, split using first 49mb and send it
-		if len(videoBytes) > 49*1024*1024 {
-			videoBytes = videoBytes[:49*1024*1024]
-		}
-
 		// Criar legenda para o vídeo
-		caption := fmt.Sprintf("🎬 #%s\n🎥 %s\n🕒 %s\n🔗 #%s",
-			event.After.Label,
-			event.After.Camera,
-			time.Unix(int64(event.After.StartTime), 0).Add(time.Duration(h.cfg.TimezoneAjust)*time.Hour).Format("02/01/2006 15:04:05"),
-			formatStringID(event.After.ID))
+		caption := h.buildCaption("🎬", event)
 
 		log.Printf("Tentando enviar clipe do evento %s (%d bytes) para o Telegram...", event.After.ID, len(videoBytes))
 
 		// Enviar vídeo pelo Telegram
-		if err := h.tgBot.SendVideo(videoCtx, videoBytes, caption, event.After.Camera); err != nil {
+		if err := h.tgBot.SendVideo(videoCtx, videoBytes, caption, event.After.Camera, opts); err != nil {
 			resultChan <- fmt.Errorf("erro ao enviar vídeo: %w", err)
 			return
 		}
@@ -163,13 +227,52 @@ func (h *AppHandler) processVideoEvent(ctx context.Context, event FrigateEvent,
 	}
 }
 
-func formatStringID(id string) string {
-	id = strings.ReplaceAll(id, "-", "")
-	result := strings.Split(id, ".")
-	if len(result) > 1 {
-		return result[1]
+// buildCaption monta a legenda usada nas fotos e vídeos de um evento
+func (h *AppHandler) buildCaption(icon string, event FrigateEvent) string {
+	lines := []string{
+		fmt.Sprintf("%s #%s", icon, event.After.Label),
+		fmt.Sprintf("🎥 %s", event.After.Camera),
+	}
+
+	if event.After.SubLabel.Name != "" {
+		lines = append(lines, fmt.Sprintf("👤 #%s", hashtag(event.After.SubLabel.Name)))
 	}
-	return id
+	if event.After.RecognizedLicensePlate.Name != "" {
+		lines = append(lines, fmt.Sprintf("🚘 #%s", hashtag(event.After.RecognizedLicensePlate.Name)))
+	}
+
+	if score := eventScore(event); score > 0 {
+		lines = append(lines, fmt.Sprintf("📊 %.0f%%", score*100))
+	}
+
+	if zones := eventZones(event); len(zones) > 0 {
+		lines = append(lines, fmt.Sprintf("📍 %s", strings.Join(zones, ", ")))
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("🕒 %s", h.localTime(time.Unix(int64(event.After.StartTime), 0)).Format("02/01/2006 15:04:05")),
+		fmt.Sprintf("🔗 #%s", frigate.ShortID(event.After.ID)),
+	)
+
+	return strings.Join(lines, "\n")
+}
+
+// localTime aplica o ajuste de fuso horário configurado
+func (h *AppHandler) localTime(t time.Time) time.Time {
+	return t.Add(time.Duration(h.cfg.TimezoneAjust) * time.Hour)
+}
+
+// hashtag converte um texto livre em uma hashtag válida do Telegram (ex.: "Maria Silva" -> "Maria_Silva")
+func hashtag(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.TrimSpace(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
 }
 
 // handleMQTTMessage é o método que processa as mensagens MQTT
@@ -182,80 +285,155 @@ func (h *AppHandler) handleMQTTMessage(client mqtt.Client, msg mqtt.Message) {
 		return
 	}
 
-	// Verificar se o evento já foi processado
 	ctx := context.Background()
-	processed, err := h.redis.IsEventProcessed(ctx, event.After.ID, event.Type)
-	if err != nil {
-		log.Printf("Erro ao verificar evento no Redis: %v", err)
-		return
-	}
-	if processed {
-		log.Printf("Evento %s (tipo: %s) já foi processado anteriormente, ignorando.", event.After.ID, event.Type)
+
+	// Os eventos criados pelo /record têm o clipe entregue pelo próprio comando
+	if h.isManualRecording(ctx, event.After.ID, event.After.Label, event.After.SubLabel.Name) {
+		log.Printf("Evento %s da câmera '%s' criado pelo /record, ignorando.", event.After.ID, event.After.Camera)
 		return
 	}
 
 	// Queremos enviar apenas para eventos novos ou atualizados que tenham snapshot
 	if (event.Type == "new" || event.Type == "update") && event.After.HasSnapshot {
-		log.Printf("Processando evento '%s' para camera '%s' (ID: %s)", event.After.Label, event.After.Camera, event.After.ID)
-
-		// Construir URL do snapshot
-		snapshotURL := fmt.Sprintf("%s/api/events/%s/snapshot.jpg", strings.TrimSuffix(h.cfg.FrigateURL, "/"), event.After.ID)
-
-		// Baixar a imagem
-		req, err := http.NewRequestWithContext(context.Background(), "GET", snapshotURL, nil)
+		// Eventos já notificados podem ter a foto substituída por uma melhor
+		notified, err := h.redis.IsEventProcessed(ctx, event.After.ID, "notified")
 		if err != nil {
-			log.Printf("Erro ao criar request para snapshot %s: %v", snapshotURL, err)
+			log.Printf("Erro ao verificar evento no Redis: %v", err)
+			return
+		}
+		if notified {
+			h.updateNotification(ctx, event)
 			return
 		}
 
-		resp, err := h.httpClient.Do(req)
-		if err != nil {
-			log.Printf("Erro ao buscar snapshot %s: %v", snapshotURL, err)
+		if h.cameraMuted(ctx, event.After.Camera) {
+			log.Printf("Evento %s da câmera '%s' descartado: câmera silenciada", event.After.ID, event.After.Camera)
+			return
+		}
+		if ok, reason := h.modeAllows(ctx, event.After.Camera, []string{event.After.Label}, rules.ActionPhoto); !ok {
+			log.Printf("Evento %s da câmera '%s' descartado: %s", event.After.ID, event.After.Camera, reason)
 			return
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			log.Printf("Erro ao buscar snapshot %s: Status %d", snapshotURL, resp.StatusCode)
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			log.Printf("Corpo da resposta: %s", string(bodyBytes))
+		opts, ok, reason := h.decide(event, rules.ActionPhoto)
+		if !ok {
+			log.Printf("Evento %s da câmera '%s' descartado: %s", event.After.ID, event.After.Camera, reason)
 			return
 		}
 
-		imgBytes, err := io.ReadAll(resp.Body)
+		allowed, err := h.checkCooldown(ctx, event)
 		if err != nil {
-			log.Printf("Erro ao ler bytes do snapshot %s: %v", snapshotURL, err)
+			log.Printf("Erro ao verificar cooldown no Redis: %v", err)
+			return
+		}
+		if !allowed {
+			log.Printf("Evento %s da câmera '%s' suprimido: cooldown de '%s' ativo", event.After.ID, event.After.Camera, event.After.Label)
 			return
 		}
 
-		// Criar legenda para a foto
-		caption := fmt.Sprintf("🖼️ #%s\n🎥 %s\n🕒 %s\n🔗 #%s",
-			event.After.Label,
-			event.After.Camera,
-			time.Unix(int64(event.After.StartTime), 0).Add(time.Duration(h.cfg.TimezoneAjust)*time.Hour).Format("02/01/2006 15:04:05"),
-			formatStringID(event.After.ID))
+		log.Printf("Processando evento '%s' para camera '%s' (ID: %s)", event.After.Label, event.After.Camera, event.After.ID)
 
-		// Enviar foto pelo Telegram
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		if err := h.tgBot.SendPhoto(ctx, imgBytes, caption, event.After.Camera); err != nil {
-			log.Printf("Erro ao enviar foto para o Telegram: %v", err)
+		imgBytes, err := h.fetchSnapshot(ctx, event.After.ID)
+		if err != nil {
+			log.Printf("Erro ao buscar snapshot do evento %s: %v", event.After.ID, err)
+			return
 		}
-		log.Printf("Foto do evento %s enviada para o Telegram.", event.After.ID)
 
-		// Marcar evento como processado após enviar a foto
-		if err := h.redis.MarkEventAsProcessed(ctx, event.After.ID, event.Type); err != nil {
+		// Marcar evento como notificado antes do envio, para que os próximos updates não gerem novas mensagens
+		if err := h.redis.MarkEventAsProcessed(ctx, event.After.ID, "notified"); err != nil {
 			log.Printf("Erro ao marcar evento como processado no Redis: %v", err)
 		}
 
+		// Indexar o ID curto da legenda, usado pelos comandos /clip e /photo
+		if err := h.redis.SaveShortID(ctx, frigate.ShortID(event.After.ID), event.After.ID); err != nil {
+			log.Printf("Erro ao indexar ID curto do evento %s no Redis: %v", event.After.ID, err)
+		}
+
+		// Criar legenda para a foto
+		caption := h.buildCaption("🖼️", event)
+
+		// A notificação leva os botões de ação do evento (exceto em álbuns com vários eventos)
+		opts.EventID = event.After.ID
+
+		// Com a janela de álbum ativa, a foto é agrupada com as de outros eventos simultâneos
+		if h.albums != nil {
+			h.albums.Add(h.albumKeyFor(event.After.Camera, opts), albumItem{
+				image:   imgBytes,
+				caption: caption,
+				camera:  event.After.Camera,
+				eventID: event.After.ID,
+				opts:    opts,
+				onSent: func(msg telegram_handler.SentMessage, caption string, album bool) {
+					h.saveNotification(event, msg, caption, album)
+				},
+			})
+			log.Printf("Foto do evento %s adicionada ao álbum.", event.After.ID)
+		} else {
+			// Enviar foto pelo Telegram
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			sent, err := h.tgBot.SendPhoto(ctx, imgBytes, caption, event.After.Camera, opts)
+			if err != nil {
+				log.Printf("Erro ao enviar foto para o Telegram: %v", err)
+				return
+			}
+			log.Printf("Foto do evento %s enviada para o Telegram.", event.After.ID)
+			h.saveNotification(event, sent, caption, false)
+		}
+
 	} else if event.Type == "end" && event.After.HasClip {
+		// Verificar se o evento já foi processado
+		processed, err := h.redis.IsEventProcessed(ctx, event.After.ID, event.Type)
+		if err != nil {
+			log.Printf("Erro ao verificar evento no Redis: %v", err)
+			return
+		}
+		if processed {
+			log.Printf("Evento %s (tipo: %s) já foi processado anteriormente, ignorando.", event.After.ID, event.Type)
+			return
+		}
+
+		if h.cameraMuted(ctx, event.After.Camera) {
+			log.Printf("Clipe do evento %s da câmera '%s' descartado: câmera silenciada", event.After.ID, event.After.Camera)
+			return
+		}
+		if ok, reason := h.modeAllows(ctx, event.After.Camera, []string{event.After.Label}, rules.ActionClip); !ok {
+			log.Printf("Clipe do evento %s da câmera '%s' descartado: %s", event.After.ID, event.After.Camera, reason)
+			return
+		}
+
+		opts, ok, reason := h.decide(event, rules.ActionClip)
+		if !ok {
+			log.Printf("Clipe do evento %s da câmera '%s' descartado: %s", event.After.ID, event.After.Camera, reason)
+			return
+		}
+
+		// O clipe é enviado como resposta ao snapshot do evento, quando ele existir
+		notification, err := h.redis.GetEventNotification(ctx, event.After.ID)
+		if err != nil {
+			log.Printf("Erro ao buscar notificação do evento %s: %v", event.After.ID, err)
+		} else if notification != nil {
+			opts.ReplyTo = notification.MessageID
+		}
+
+		// Eventos suprimidos pelo cooldown (e sem foto enviada) também não geram clipe
+		suppressed, err := h.redis.IsEventProcessed(ctx, event.After.ID, "suppressed")
+		if err != nil {
+			log.Printf("Erro ao verificar evento no Redis: %v", err)
+			return
+		}
+		if suppressed && notification == nil {
+			log.Printf("Clipe do evento %s da câmera '%s' descartado: evento suprimido pelo cooldown", event.After.ID, event.After.Camera)
+			return
+		}
+
 		log.Printf("Processando fim de evento '%s' para camera '%s' (ID: %s) - Enviando clipe.", event.After.Label, event.After.Camera, event.After.ID)
 
 		// Construir URL do clipe
 		clipURL := fmt.Sprintf("%s/api/events/%s/clip.mp4", strings.TrimSuffix(h.cfg.FrigateURL, "/"), event.After.ID)
 
 		// Processar o vídeo em uma goroutine separada
-		go h.processVideoEvent(context.Background(), event, clipURL)
+		go h.processVideoEvent(context.Background(), event, clipURL, opts)
 
 		// Marcar evento como processado após iniciar o processamento do vídeo
 		if err := h.redis.MarkEventAsProcessed(ctx, event.After.ID, event.Type); err != nil {
@@ -268,6 +446,9 @@ func (h *AppHandler) handleMQTTMessage(client mqtt.Client, msg mqtt.Message) {
 }
 
 func main() {
+	explain := flag.String("explain", "", "avalia as regras para o evento JSON do arquivo informado (- para stdin) e sai")
+	flag.Parse()
+
 	fmt.Println("Iniciando Frigate Events Telegram...")
 
 	// Carregar configuração
@@ -276,6 +457,19 @@ func main() {
 		log.Fatalf("Erro ao carregar configuração: %v", err)
 	}
 
+	// Compilar as regras de notificação
+	engine, err := rules.NewEngine(cfg.Rules)
+	if err != nil {
+		log.Fatalf("Erro ao carregar regras: %v", err)
+	}
+
+	if *explain != "" {
+		if err := explainEvent(cfg, engine, *explain); err != nil {
+			log.Fatalf("Erro ao avaliar evento: %v", err)
+		}
+		return
+	}
+
 	// Inicializar Redis
 	redis, err := redis_handler.NewRedisHandler(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
 	if err != nil {
@@ -286,6 +480,10 @@ func main() {
 	// Inicializar Frigate
 	frigate := frigate.NewFrigate(cfg.FrigateURL)
 
+	// Os chats de destino das regras também recebem os botões de ação
+	auth := cfg.Auth
+	auth.Chats = cfg.AllowedChats()
+
 	// Inicializar bot do Telegram
 	tgBot, err := telegram_handler.NewBot(telegram_handler.TelegramBot{
 		Token:         cfg.TelegramToken,
@@ -294,6 +492,9 @@ func main() {
 		UseThreadIDs:  cfg.UseThreadIDs,
 		Redis:         redis,
 		Frigate:       frigate,
+		TimezoneAjust: cfg.TimezoneAjust,
+		Modes:         cfg.Modes,
+		Auth:          auth,
 	})
 	if err != nil {
 		log.Fatalf("Erro ao inicializar bot do Telegram: %v", err)
@@ -315,12 +516,49 @@ func main() {
 	}
 
 	// Criar o handler da aplicação
-	appHandler := newAppHandler(tgBot, cfg, redis)
+	appHandler := newAppHandler(tgBot, cfg, redis, engine, frigate)
 	if !cfg.CheckTelegram {
 		// Inscrever Sno tópico de eventos do Frigate usando o método do handler
-		if err := mqttClient.Subscribe(cfg.MQTTTopic, 1, appHandler.handleMQTTMessage); err != nil {
-			log.Fatalf("Erro ao inscrever no tópico MQTT: %v", err)
+		if cfg.EventSource == config.EventSourceReviews {
+			if err := mqttClient.Subscribe(cfg.TopicFor("reviews"), 1, appHandler.handleReviewMessage); err != nil {
+				log.Fatalf("Erro ao inscrever no tópico MQTT: %v", err)
+			}
+		} else {
+			if err := mqttClient.Subscribe(cfg.MQTTTopic, 1, appHandler.handleMQTTMessage); err != nil {
+				log.Fatalf("Erro ao inscrever no tópico MQTT: %v", err)
+			}
+		}
+
+		// Descrições geradas pela GenAI do Frigate
+		if cfg.GenAI.Enabled {
+			if err := mqttClient.Subscribe(cfg.TopicFor("tracked_object_update"), 1, appHandler.handleTrackedObjectUpdate); err != nil {
+				log.Fatalf("Erro ao inscrever no tópico MQTT: %v", err)
+			}
 		}
+
+		// Troca de modo pelo MQTT (ex.: automações do Home Assistant)
+		if len(cfg.Modes) > 0 {
+			if err := mqttClient.Subscribe(cfg.ModeTopic, 1, appHandler.handleModeCommand); err != nil {
+				log.Fatalf("Erro ao inscrever no tópico MQTT: %v", err)
+			}
+		}
+
+		// Enviar resumo dos eventos suprimidos pelo cooldown
+		go appHandler.runCooldownSummary(ctx, 15*time.Second)
+
+		// Monitorar a disponibilidade do Frigate e das câmeras
+		if cfg.Health.Enabled {
+			monitor := newHealthMonitor(appHandler)
+			if err := mqttClient.Subscribe(cfg.TopicFor("available"), 1, monitor.handleAvailability); err != nil {
+				log.Fatalf("Erro ao inscrever no tópico MQTT: %v", err)
+			}
+			go monitor.run(ctx)
+		}
+	}
+
+	// Monitorar o uso do disco de gravações
+	if cfg.Storage.Enabled && !cfg.CheckTelegram {
+		go newStorageMonitor(appHandler).run(ctx)
 	}
 
 	// Enviar mensagem de inicialização para o Telegram
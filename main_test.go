@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestScoredLabelUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    ScoredLabel
+		wantErr bool
+	}{
+		{"string", `"Maria"`, ScoredLabel{Name: "Maria"}, false},
+		{"string vazia", `""`, ScoredLabel{}, false},
+		{"nome e score", `["ABC1D23", 0.92]`, ScoredLabel{Name: "ABC1D23", Score: 0.92}, false},
+		{"apenas nome", `["Maria"]`, ScoredLabel{Name: "Maria"}, false},
+		{"lista vazia", `[]`, ScoredLabel{}, false},
+		{"null", `null`, ScoredLabel{}, false},
+		{"número", `42`, ScoredLabel{}, true},
+		{"objeto", `{"name": "Maria"}`, ScoredLabel{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ScoredLabel
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) erro = %v, esperado erro = %v", tt.data, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %+v, esperado %+v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrigateEventSubLabelFormats(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		wantSub   string
+		wantPlate string
+	}{
+		{"Frigate 0.13: sub_label string", `{"after": {"sub_label": "Maria", "recognized_license_plate": null}}`, "Maria", ""},
+		{"Frigate 0.14+: [nome, score]", `{"after": {"sub_label": ["Maria", 0.87], "recognized_license_plate": ["ABC1D23", 0.95]}}`, "Maria", "ABC1D23"},
+		{"sem sub_label", `{"after": {"sub_label": null}}`, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event FrigateEvent
+			if err := json.Unmarshal([]byte(tt.payload), &event); err != nil {
+				t.Fatalf("Unmarshal erro = %v", err)
+			}
+			if event.After.SubLabel.Name != tt.wantSub || event.After.RecognizedLicensePlate.Name != tt.wantPlate {
+				t.Errorf("sub_label = %q, placa = %q, esperado %q e %q", event.After.SubLabel.Name, event.After.RecognizedLicensePlate.Name, tt.wantSub, tt.wantPlate)
+			}
+		})
+	}
+}
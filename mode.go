@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/rules"
+)
+
+// modeAllows verifica se o modo ativo permite a notificação da câmera com alguma das labels.
+// media indica se é a foto (rules.ActionPhoto) ou o clipe (rules.ActionClip).
+// Sem modo ativo tudo é permitido; em caso de erro no Redis a notificação é enviada normalmente.
+func (h *AppHandler) modeAllows(ctx context.Context, camera string, labels []string, media string) (bool, string) {
+	name, err := h.redis.GetActiveMode(ctx)
+	if err != nil {
+		log.Printf("Erro ao buscar modo ativo no Redis: %v", err)
+		return true, ""
+	}
+	if name == "" {
+		return true, ""
+	}
+
+	mode := h.cfg.GetMode(name)
+	if mode == nil {
+		log.Printf("Aviso: modo ativo '%s' não existe mais na configuração, ignorando.", name)
+		return true, ""
+	}
+
+	if media == rules.ActionClip && !mode.SendClips() {
+		return false, fmt.Sprintf("modo %s não envia clipes", mode.Name)
+	}
+	if !mode.AllowsCamera(camera) {
+		return false, fmt.Sprintf("câmera fora do modo %s", mode.Name)
+	}
+	for _, label := range labels {
+		if mode.Allows(camera, label) {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("label fora do modo %s", mode.Name)
+}
+
+// handleModeCommand troca o modo a partir do tópico MQTT de comando (payload: nome do modo ou "disarm")
+func (h *AppHandler) handleModeCommand(client mqtt.Client, msg mqtt.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := h.tgBot.SetMode(ctx, string(msg.Payload()), "MQTT"); err != nil {
+		log.Printf("Erro ao trocar modo pelo MQTT: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"context"
+	"log"
+)
+
+// cameraMuted verifica se as notificações da câmera foram silenciadas pelo Telegram.
+// Em caso de erro no Redis a notificação é enviada normalmente.
+func (h *AppHandler) cameraMuted(ctx context.Context, camera string) bool {
+	muted, err := h.redis.IsCameraMuted(ctx, camera)
+	if err != nil {
+		log.Printf("Erro ao verificar silêncio da câmera '%s' no Redis: %v", camera, err)
+		return false
+	}
+	return muted
+}
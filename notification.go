@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/redis_handler"
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+// saveNotification guarda no Redis a mensagem enviada para o evento, para permitir editá-la depois
+func (h *AppHandler) saveNotification(event FrigateEvent, msg telegram_handler.SentMessage, caption string, album bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notification := redis_handler.EventNotification{
+		ChatID:    msg.ChatID,
+		MessageID: msg.MessageID,
+		ThreadID:  msg.ThreadID,
+		TopScore:  eventScore(event),
+		SubLabel:  event.After.SubLabel.Name,
+		Caption:   caption,
+		Album:     album,
+		Camera:    event.After.Camera,
+		Actions:   msg.EventID != "",
+	}
+	if err := h.redis.SaveEventNotification(ctx, event.After.ID, notification); err != nil {
+		log.Printf("Erro ao salvar notificação do evento %s no Redis: %v", event.After.ID, err)
+	}
+}
+
+// notificationMessage identifica a mensagem de uma notificação guardada no Redis, incluindo os
+// botões de ação para que sejam mantidos nas edições
+func notificationMessage(eventID string, notification *redis_handler.EventNotification) telegram_handler.SentMessage {
+	msg := telegram_handler.SentMessage{ChatID: notification.ChatID, MessageID: notification.MessageID, ThreadID: notification.ThreadID}
+	if notification.Actions {
+		msg.EventID = eventID
+	}
+	return msg
+}
+
+// updateNotification substitui a foto da notificação já enviada quando o update traz um
+// snapshot melhor (top_score maior) ou um novo sub_label
+func (h *AppHandler) updateNotification(ctx context.Context, event FrigateEvent) {
+	if !h.cfg.EditSnapshots {
+		return
+	}
+
+	notification, err := h.redis.GetEventNotification(ctx, event.After.ID)
+	if err != nil {
+		log.Printf("Erro ao buscar notificação do evento %s: %v", event.After.ID, err)
+		return
+	}
+	if notification == nil {
+		// A foto ainda não foi enviada (ex.: aguardando o álbum)
+		return
+	}
+
+	betterScore := eventScore(event) >= notification.TopScore+h.cfg.EditMinScoreGain
+	newSubLabel := event.After.SubLabel.Name != "" && event.After.SubLabel.Name != notification.SubLabel
+	if !betterScore && !newSubLabel {
+		return
+	}
+
+	imgBytes, err := h.fetchSnapshot(ctx, event.After.ID)
+	if err != nil {
+		log.Printf("Erro ao buscar snapshot do evento %s: %v", event.After.ID, err)
+		return
+	}
+
+	// Em álbuns a legenda é compartilhada entre os eventos, então é mantida
+	caption := notification.Caption
+	if !notification.Album {
+		caption = withDescription(h.buildCaption("🖼️", event), notification.Description)
+	}
+
+	editCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	msg := notificationMessage(event.After.ID, notification)
+	if err := h.tgBot.EditPhoto(editCtx, msg, imgBytes, caption); err != nil {
+		log.Printf("Erro ao atualizar foto do evento %s no Telegram: %v", event.After.ID, err)
+		return
+	}
+	log.Printf("Foto do evento %s atualizada no Telegram (score %.0f%% -> %.0f%%).", event.After.ID, notification.TopScore*100, eventScore(event)*100)
+
+	notification.TopScore = eventScore(event)
+	notification.SubLabel = event.After.SubLabel.Name
+	notification.Caption = caption
+	if err := h.redis.SaveEventNotification(ctx, event.After.ID, *notification); err != nil {
+		log.Printf("Erro ao salvar notificação do evento %s no Redis: %v", event.After.ID, err)
+	}
+}
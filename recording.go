@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+)
+
+// isManualRecording verifica se o evento foi criado pelo /record, que entrega o próprio clipe
+// como resposta ao comando (pela label/sub_label do evento ou pela marcação feita ao criá-lo)
+func (h *AppHandler) isManualRecording(ctx context.Context, eventID string, labels ...string) bool {
+	if containsLabel(labels, frigate.ManualEventLabel) {
+		return true
+	}
+
+	manual, err := h.redis.IsEventProcessed(ctx, eventID, frigate.ManualEventLabel)
+	if err != nil {
+		log.Printf("Erro ao verificar evento manual no Redis: %v", err)
+		return false
+	}
+	return manual
+}
+
+// isManualReview verifica se o review contém um evento criado pelo /record
+func (h *AppHandler) isManualReview(ctx context.Context, review FrigateReview) bool {
+	labels := append(append([]string{}, review.After.Data.Objects...), review.After.Data.SubLabels...)
+	if containsLabel(labels, frigate.ManualEventLabel) {
+		return true
+	}
+	for _, eventID := range review.After.Data.Detections {
+		if h.isManualRecording(ctx, eventID) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,12 +2,20 @@ package redis_handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// eventTTL é o tempo que as informações de um evento ficam guardadas no Redis
+const eventTTL = 2 * time.Hour
+
+// shortIDTTL é o tempo que o índice de IDs curtos fica guardado (próximo da retenção de clipes do Frigate)
+const shortIDTTL = 7 * 24 * time.Hour
+
 // RedisHandler gerencia a conexão com o Redis
 type RedisHandler struct {
 	client *redis.Client
@@ -52,7 +60,7 @@ func (h *RedisHandler) MarkEventAsProcessed(ctx context.Context, eventID string,
 	key := fmt.Sprintf("frigate:event:%s:%s", eventType, eventID)
 
 	// Usar SET com opção EX para definir a expiração em segundos (2 horas = 7200 segundos)
-	if err := h.client.Set(ctx, key, "processed", 2*time.Hour).Err(); err != nil {
+	if err := h.client.Set(ctx, key, "processed", eventTTL).Err(); err != nil {
 		return fmt.Errorf("erro ao marcar evento como processado: %w", err)
 	}
 
@@ -68,6 +76,309 @@ func (h *RedisHandler) MarkEventAsProcessed(ctx context.Context, eventID string,
 	return nil
 }
 
+// EventNotification guarda a mensagem do Telegram enviada para um evento
+type EventNotification struct {
+	ChatID      int64   `json:"chat_id"`
+	MessageID   int     `json:"message_id"`
+	ThreadID    int     `json:"thread_id"`
+	TopScore    float64 `json:"top_score"`
+	SubLabel    string  `json:"sub_label"`
+	Caption     string  `json:"caption"`
+	Album       bool    `json:"album"`       // true quando a foto faz parte de um álbum com outros eventos
+	Description string  `json:"description"` // descrição gerada pela GenAI do Frigate
+	Camera      string  `json:"camera"`
+	Actions     bool    `json:"actions"` // true quando a foto foi enviada com os botões de ação
+}
+
+// SaveEventNotification guarda a mensagem enviada para um evento
+func (h *RedisHandler) SaveEventNotification(ctx context.Context, eventID string, notification EventNotification) error {
+	key := fmt.Sprintf("frigate:message:%s", eventID)
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar notificação: %w", err)
+	}
+	if err := h.client.Set(ctx, key, data, eventTTL).Err(); err != nil {
+		return fmt.Errorf("erro ao salvar notificação: %w", err)
+	}
+	return nil
+}
+
+// GetEventNotification retorna a mensagem enviada para um evento, ou nil se não existir
+func (h *RedisHandler) GetEventNotification(ctx context.Context, eventID string) (*EventNotification, error) {
+	key := fmt.Sprintf("frigate:message:%s", eventID)
+	data, err := h.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar notificação: %w", err)
+	}
+
+	var notification EventNotification
+	if err := json.Unmarshal(data, &notification); err != nil {
+		return nil, fmt.Errorf("erro ao deserializar notificação: %w", err)
+	}
+	return &notification, nil
+}
+
+// SaveShortID guarda o ID completo do evento correspondente a um ID curto (usado nas legendas)
+func (h *RedisHandler) SaveShortID(ctx context.Context, shortID, eventID string) error {
+	key := fmt.Sprintf("frigate:shortid:%s", strings.ToLower(shortID))
+	if err := h.client.Set(ctx, key, eventID, shortIDTTL).Err(); err != nil {
+		return fmt.Errorf("erro ao salvar ID curto: %w", err)
+	}
+	return nil
+}
+
+// GetEventIDByShortID retorna o ID completo do evento de um ID curto, ou "" se não existir
+func (h *RedisHandler) GetEventIDByShortID(ctx context.Context, shortID string) (string, error) {
+	key := fmt.Sprintf("frigate:shortid:%s", strings.ToLower(shortID))
+	eventID, err := h.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar ID curto: %w", err)
+	}
+	return eventID, nil
+}
+
+// SaveEventAction registra quem executou uma ação (botão) na notificação de um evento
+func (h *RedisHandler) SaveEventAction(ctx context.Context, eventID, action, user string) error {
+	key := fmt.Sprintf("frigate:actions:%s", eventID)
+	if err := h.client.HSet(ctx, key, action, user).Err(); err != nil {
+		return fmt.Errorf("erro ao salvar ação do evento: %w", err)
+	}
+	if err := h.client.Expire(ctx, key, eventTTL).Err(); err != nil {
+		return fmt.Errorf("erro ao definir TTL da ação do evento: %w", err)
+	}
+	return nil
+}
+
+// GetEventActions retorna as ações já executadas na notificação de um evento (ação -> usuário)
+func (h *RedisHandler) GetEventActions(ctx context.Context, eventID string) (map[string]string, error) {
+	key := fmt.Sprintf("frigate:actions:%s", eventID)
+	actions, err := h.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar ações do evento: %w", err)
+	}
+	return actions, nil
+}
+
+// AllCameras identifica o silêncio de todas as câmeras
+const AllCameras = "*"
+
+// MuteCamera silencia as notificações de uma câmera (ou de todas, com AllCameras) pelo tempo informado
+func (h *RedisHandler) MuteCamera(ctx context.Context, camera string, duration time.Duration) error {
+	key := fmt.Sprintf("frigate:mute:%s", camera)
+	if err := h.client.Set(ctx, key, time.Now().Add(duration).Unix(), duration).Err(); err != nil {
+		return fmt.Errorf("erro ao silenciar câmera: %w", err)
+	}
+	return nil
+}
+
+// UnmuteCamera encerra o silêncio de uma câmera. Com AllCameras, encerra o silêncio de todas as câmeras.
+// Retorna false quando não havia silêncio ativo.
+func (h *RedisHandler) UnmuteCamera(ctx context.Context, camera string) (bool, error) {
+	keys := []string{fmt.Sprintf("frigate:mute:%s", camera)}
+	if camera == AllCameras {
+		mutes, err := h.ListMutes(ctx)
+		if err != nil {
+			return false, err
+		}
+		keys = keys[:0]
+		for _, mute := range mutes {
+			keys = append(keys, fmt.Sprintf("frigate:mute:%s", mute.Camera))
+		}
+		if len(keys) == 0 {
+			return false, nil
+		}
+	}
+
+	deleted, err := h.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("erro ao encerrar silêncio da câmera: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// IsCameraMuted verifica se as notificações de uma câmera estão silenciadas (pela câmera ou por todas)
+func (h *RedisHandler) IsCameraMuted(ctx context.Context, camera string) (bool, error) {
+	exists, err := h.client.Exists(ctx, fmt.Sprintf("frigate:mute:%s", camera), fmt.Sprintf("frigate:mute:%s", AllCameras)).Result()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar silêncio da câmera: %w", err)
+	}
+	return exists > 0, nil
+}
+
+// Mute representa o silêncio ativo de uma câmera
+type Mute struct {
+	Camera    string // AllCameras quando todas as câmeras estão silenciadas
+	Remaining time.Duration
+}
+
+// ListMutes retorna os silêncios ativos com o tempo restante de cada um
+func (h *RedisHandler) ListMutes(ctx context.Context) ([]Mute, error) {
+	var result []Mute
+
+	iter := h.client.Scan(ctx, 0, "frigate:mute:*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		ttl, err := h.client.TTL(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao verificar TTL do silêncio: %w", err)
+		}
+		// A chave pode ter expirado durante a listagem
+		if ttl <= 0 {
+			continue
+		}
+		result = append(result, Mute{Camera: strings.TrimPrefix(key, "frigate:mute:"), Remaining: ttl})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao listar silêncios: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetActiveMode retorna o modo ativo ("" = desarmado)
+func (h *RedisHandler) GetActiveMode(ctx context.Context) (string, error) {
+	mode, err := h.client.Get(ctx, "frigate:mode").Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar modo ativo: %w", err)
+	}
+	return mode, nil
+}
+
+// SetActiveMode define o modo ativo ("" desarma)
+func (h *RedisHandler) SetActiveMode(ctx context.Context, mode string) error {
+	var err error
+	if mode == "" {
+		err = h.client.Del(ctx, "frigate:mode").Err()
+	} else {
+		err = h.client.Set(ctx, "frigate:mode", mode, 0).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao salvar modo ativo: %w", err)
+	}
+	return nil
+}
+
+// SuppressedCount representa a quantidade de eventos suprimidos pelo cooldown de uma câmera+label
+type SuppressedCount struct {
+	Camera string
+	Label  string
+	Count  int64
+}
+
+// AcquireCooldown inicia a janela de cooldown da câmera+label para o evento informado, caso não exista.
+// Retorna true se o evento é o dono da janela atual (pode ser notificado).
+func (h *RedisHandler) AcquireCooldown(ctx context.Context, camera, label, eventID string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("frigate:cooldown:%s:%s", camera, label)
+
+	if _, err := h.client.SetNX(ctx, key, eventID, ttl).Result(); err != nil {
+		return false, fmt.Errorf("erro ao iniciar cooldown: %w", err)
+	}
+
+	owner, err := h.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		// A janela expirou entre o SETNX e o GET
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar cooldown: %w", err)
+	}
+	return owner == eventID, nil
+}
+
+// IncrSuppressed incrementa o contador de eventos suprimidos da câmera+label
+func (h *RedisHandler) IncrSuppressed(ctx context.Context, camera, label string) error {
+	key := fmt.Sprintf("frigate:suppressed:%s:%s", camera, label)
+	if err := h.client.Incr(ctx, key).Err(); err != nil {
+		return fmt.Errorf("erro ao incrementar eventos suprimidos: %w", err)
+	}
+	return nil
+}
+
+// PopExpiredSuppressed retorna e remove os contadores de eventos suprimidos cuja janela de cooldown já terminou
+func (h *RedisHandler) PopExpiredSuppressed(ctx context.Context) ([]SuppressedCount, error) {
+	var result []SuppressedCount
+
+	iter := h.client.Scan(ctx, 0, "frigate:suppressed:*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		parts := strings.SplitN(strings.TrimPrefix(key, "frigate:suppressed:"), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		active, err := h.client.Exists(ctx, fmt.Sprintf("frigate:cooldown:%s:%s", parts[0], parts[1])).Result()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao verificar cooldown: %w", err)
+		}
+		if active > 0 {
+			continue
+		}
+
+		count, err := h.client.GetDel(ctx, key).Int64()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler eventos suprimidos: %w", err)
+		}
+		result = append(result, SuppressedCount{Camera: parts[0], Label: parts[1], Count: count})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao listar eventos suprimidos: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetStorageAlertLevel retorna o último limite de uso de disco alertado (0 = nenhum)
+func (h *RedisHandler) GetStorageAlertLevel(ctx context.Context) (float64, error) {
+	level, err := h.client.Get(ctx, "frigate:storage:alert_level").Float64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar alerta de armazenamento: %w", err)
+	}
+	return level, nil
+}
+
+// SetStorageAlertLevel guarda o último limite de uso de disco alertado
+func (h *RedisHandler) SetStorageAlertLevel(ctx context.Context, level float64) error {
+	if err := h.client.Set(ctx, "frigate:storage:alert_level", level, 0).Err(); err != nil {
+		return fmt.Errorf("erro ao salvar alerta de armazenamento: %w", err)
+	}
+	return nil
+}
+
+// GetLastStorageSummary retorna a semana (ex.: "2026-42") do último resumo de armazenamento enviado
+func (h *RedisHandler) GetLastStorageSummary(ctx context.Context) (string, error) {
+	week, err := h.client.Get(ctx, "frigate:storage:summary").Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar resumo de armazenamento: %w", err)
+	}
+	return week, nil
+}
+
+// SetLastStorageSummary guarda a semana do último resumo de armazenamento enviado
+func (h *RedisHandler) SetLastStorageSummary(ctx context.Context, week string) error {
+	if err := h.client.Set(ctx, "frigate:storage:summary", week, 0).Err(); err != nil {
+		return fmt.Errorf("erro ao salvar resumo de armazenamento: %w", err)
+	}
+	return nil
+}
+
 // Close fecha a conexão com o Redis
 func (h *RedisHandler) Close() error {
 	return h.client.Close()
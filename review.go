@@ -0,0 +1,427 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+	"github.com/geffersonFerraz/frigate-events-telegram/redis_handler"
+	"github.com/geffersonFerraz/frigate-events-telegram/rules"
+	"github.com/geffersonFerraz/frigate-events-telegram/telegram_handler"
+)
+
+// FrigateReviewData representa um review item do Frigate 0.14+, que agrupa vários objetos rastreados
+type FrigateReviewData struct {
+	ID        string  `json:"id"`
+	Camera    string  `json:"camera"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+	Severity  string  `json:"severity"` // "alert" ou "detection"
+	Data      struct {
+		Detections []string `json:"detections"` // IDs dos eventos (objetos) do review
+		Objects    []string `json:"objects"`
+		SubLabels  []string `json:"sub_labels"`
+		Zones      []string `json:"zones"`
+	} `json:"data"`
+}
+
+// FrigateReview representa uma mensagem do tópico frigate/reviews
+type FrigateReview struct {
+	Before FrigateReviewData `json:"before"`
+	After  FrigateReviewData `json:"after"`
+	Type   string            `json:"type"` // "new", "update", "end"
+}
+
+// reviewEvent converte um objeto do review em um evento, para aplicar as mesmas regras e filtros dos eventos
+func reviewEvent(review FrigateReview, label string) FrigateEvent {
+	event := FrigateEvent{Type: review.Type}
+	event.After.ID = review.After.ID
+	event.After.Camera = review.After.Camera
+	event.After.Label = label
+	event.After.StartTime = review.After.StartTime
+	event.After.EnteredZones = review.After.Data.Zones
+	if len(review.After.Data.SubLabels) > 0 {
+		event.After.SubLabel.Name = review.After.Data.SubLabels[0]
+	}
+	return event
+}
+
+// decideReview aplica as regras e filtros a cada objeto do review.
+// Retorna as labels que podem ser notificadas e as opções de envio da primeira delas.
+func (h *AppHandler) decideReview(review FrigateReview, media string) ([]string, telegram_handler.SendOptions, string) {
+	var labels []string
+	var opts telegram_handler.SendOptions
+	var reasons []string
+
+	for _, label := range review.After.Data.Objects {
+		labelOpts, ok, reason := h.decide(reviewEvent(review, label), media)
+		if !ok {
+			reasons = append(reasons, reason)
+			continue
+		}
+		if len(labels) == 0 {
+			opts = labelOpts
+		}
+		labels = append(labels, label)
+	}
+
+	// Alerts e detections são enviados em mensagens separadas e podem ter threads próprias
+	threadID := h.cfg.Reviews.DetectionThreadID
+	if review.After.Severity == "alert" {
+		threadID = h.cfg.Reviews.AlertThreadID
+	} else if h.cfg.Reviews.DetectionsSilent {
+		opts.Silent = true
+	}
+	if opts.ThreadID == 0 && opts.ChatID == 0 {
+		opts.ThreadID = threadID
+	}
+
+	return labels, opts, strings.Join(reasons, "; ")
+}
+
+// buildReviewCaption monta a legenda de um review, listando todas as labels dos objetos
+func (h *AppHandler) buildReviewCaption(icon string, review FrigateReview, labels []string) string {
+	severity := "👀 Detecção"
+	if review.After.Severity == "alert" {
+		severity = "🚨 Alerta"
+	}
+
+	tags := make([]string, 0, len(labels))
+	for _, label := range labels {
+		tags = append(tags, "#"+hashtag(label))
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s %s", icon, severity),
+		fmt.Sprintf("🏷️ %s", strings.Join(tags, " ")),
+		fmt.Sprintf("🎥 %s", review.After.Camera),
+	}
+	if len(review.After.Data.SubLabels) > 0 {
+		subLabels := make([]string, 0, len(review.After.Data.SubLabels))
+		for _, subLabel := range review.After.Data.SubLabels {
+			subLabels = append(subLabels, "#"+hashtag(subLabel))
+		}
+		lines = append(lines, fmt.Sprintf("👤 %s", strings.Join(subLabels, " ")))
+	}
+	if len(review.After.Data.Zones) > 0 {
+		lines = append(lines, fmt.Sprintf("📍 %s", strings.Join(review.After.Data.Zones, ", ")))
+	}
+	lines = append(lines,
+		fmt.Sprintf("🕒 %s", h.localTime(time.Unix(int64(review.After.StartTime), 0)).Format("02/01/2006 15:04:05")),
+		fmt.Sprintf("🔗 #%s", frigate.ShortID(review.After.ID)),
+	)
+	return strings.Join(lines, "\n")
+}
+
+// handleReviewMessage processa as mensagens do tópico frigate/reviews: uma mensagem por review
+// (e por severidade) com a foto do primeiro objeto e, no fim do review, a prévia em vídeo como resposta
+func (h *AppHandler) handleReviewMessage(client mqtt.Client, msg mqtt.Message) {
+	fmt.Printf("Recebido: %s do tópico: %s\n", msg.Payload(), msg.Topic())
+
+	var review FrigateReview
+	if err := json.Unmarshal(msg.Payload(), &review); err != nil {
+		log.Printf("Erro ao decodificar JSON do review: %v", err)
+		return
+	}
+	if !containsLabel(h.cfg.Reviews.Severities, review.After.Severity) {
+		return
+	}
+
+	ctx := context.Background()
+	if h.isManualReview(ctx, review) {
+		log.Printf("Review %s da câmera '%s' contém evento criado pelo /record, ignorando.", review.After.ID, review.After.Camera)
+		return
+	}
+
+	// A severidade faz parte da chave: um review promovido de detection para alert gera uma nova mensagem
+	reviewKey := fmt.Sprintf("review-%s-%s", review.After.Severity, review.After.ID)
+
+	switch review.Type {
+	case "new", "update":
+		notification, err := h.redis.GetEventNotification(ctx, reviewKey)
+		if err != nil {
+			log.Printf("Erro ao buscar notificação do review %s: %v", review.After.ID, err)
+			return
+		}
+		if notification != nil {
+			h.updateReviewNotification(ctx, review, reviewKey)
+			return
+		}
+
+		notified, err := h.redis.IsEventProcessed(ctx, reviewKey, "notified")
+		if err != nil {
+			log.Printf("Erro ao verificar review no Redis: %v", err)
+			return
+		}
+		if notified {
+			return
+		}
+
+		if h.cameraMuted(ctx, review.After.Camera) {
+			log.Printf("Review %s da câmera '%s' descartado: câmera silenciada", review.After.ID, review.After.Camera)
+			return
+		}
+
+		labels, opts, reason := h.decideReview(review, rules.ActionPhoto)
+		if len(labels) == 0 {
+			log.Printf("Review %s da câmera '%s' descartado: %s", review.After.ID, review.After.Camera, reason)
+			return
+		}
+		if ok, reason := h.modeAllows(ctx, review.After.Camera, labels, rules.ActionPhoto); !ok {
+			log.Printf("Review %s da câmera '%s' descartado: %s", review.After.ID, review.After.Camera, reason)
+			return
+		}
+		if len(review.After.Data.Detections) == 0 {
+			log.Printf("Review %s da câmera '%s' sem objetos rastreados, ignorando.", review.After.ID, review.After.Camera)
+			return
+		}
+
+		allowed, err := h.checkReviewCooldown(ctx, review, reviewKey, labels)
+		if err != nil {
+			log.Printf("Erro ao verificar cooldown no Redis: %v", err)
+			return
+		}
+		if !allowed {
+			log.Printf("Review %s da câmera '%s' suprimido: cooldown de %s ativo", review.After.ID, review.After.Camera, strings.Join(labels, ", "))
+			return
+		}
+
+		imgBytes, err := h.fetchSnapshot(ctx, review.After.Data.Detections[0])
+		if err != nil {
+			log.Printf("Erro ao buscar snapshot do review %s: %v", review.After.ID, err)
+			return
+		}
+
+		if err := h.redis.MarkEventAsProcessed(ctx, reviewKey, "notified"); err != nil {
+			log.Printf("Erro ao marcar review como processado no Redis: %v", err)
+		}
+
+		// O ID curto do review aponta para o primeiro objeto rastreado, usado pelos comandos /clip e /photo
+		if err := h.redis.SaveShortID(ctx, frigate.ShortID(review.After.ID), review.After.Data.Detections[0]); err != nil {
+			log.Printf("Erro ao indexar ID curto do review %s no Redis: %v", review.After.ID, err)
+		}
+
+		caption := h.buildReviewCaption("🖼️", review, labels)
+		topScore := h.reviewScore(ctx, review)
+
+		// Os botões de ação do review atuam sobre o primeiro objeto rastreado
+		opts.EventID = review.After.Data.Detections[0]
+
+		// Com a janela de álbum ativa, a foto é agrupada com as de outros eventos simultâneos
+		if h.albums != nil {
+			h.albums.Add(h.albumKeyFor(review.After.Camera, opts), albumItem{
+				image:   imgBytes,
+				caption: caption,
+				camera:  review.After.Camera,
+				eventID: review.After.ID,
+				opts:    opts,
+				onSent: func(msg telegram_handler.SentMessage, caption string, album bool) {
+					h.saveReviewNotification(review, reviewKey, msg, caption, album, topScore)
+				},
+			})
+			log.Printf("Foto do review %s adicionada ao álbum.", review.After.ID)
+			return
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		sent, err := h.tgBot.SendPhoto(sendCtx, imgBytes, caption, review.After.Camera, opts)
+		if err != nil {
+			log.Printf("Erro ao enviar foto do review para o Telegram: %v", err)
+			return
+		}
+		log.Printf("Foto do review %s enviada para o Telegram.", review.After.ID)
+		h.saveReviewNotification(review, reviewKey, sent, caption, false, topScore)
+
+	case "end":
+		processed, err := h.redis.IsEventProcessed(ctx, reviewKey, "end")
+		if err != nil {
+			log.Printf("Erro ao verificar review no Redis: %v", err)
+			return
+		}
+		if processed {
+			return
+		}
+
+		if h.cameraMuted(ctx, review.After.Camera) {
+			log.Printf("Prévia do review %s da câmera '%s' descartada: câmera silenciada", review.After.ID, review.After.Camera)
+			return
+		}
+
+		labels, opts, reason := h.decideReview(review, rules.ActionClip)
+		if len(labels) == 0 {
+			log.Printf("Prévia do review %s da câmera '%s' descartada: %s", review.After.ID, review.After.Camera, reason)
+			return
+		}
+		if ok, reason := h.modeAllows(ctx, review.After.Camera, labels, rules.ActionClip); !ok {
+			log.Printf("Prévia do review %s da câmera '%s' descartada: %s", review.After.ID, review.After.Camera, reason)
+			return
+		}
+
+		notification, err := h.redis.GetEventNotification(ctx, reviewKey)
+		if err != nil {
+			log.Printf("Erro ao buscar notificação do review %s: %v", review.After.ID, err)
+		} else if notification != nil {
+			opts.ReplyTo = notification.MessageID
+		}
+
+		// Reviews suprimidos pelo cooldown (e não notificados depois) também não geram prévia
+		suppressed, err := h.redis.IsEventProcessed(ctx, reviewKey, "suppressed")
+		if err != nil {
+			log.Printf("Erro ao verificar review no Redis: %v", err)
+			return
+		}
+		if suppressed && notification == nil {
+			log.Printf("Prévia do review %s da câmera '%s' descartada: review suprimido pelo cooldown", review.After.ID, review.After.Camera)
+			return
+		}
+
+		if err := h.redis.MarkEventAsProcessed(ctx, reviewKey, "end"); err != nil {
+			log.Printf("Erro ao marcar review como processado no Redis: %v", err)
+		}
+
+		go h.processReviewPreview(review, labels, opts)
+	}
+}
+
+// checkReviewCooldown aplica o cooldown de cada label do review. O review só é suprimido quando todas
+// as labels estão em cooldown; nesse caso ele é marcado para que a prévia também não seja enviada.
+func (h *AppHandler) checkReviewCooldown(ctx context.Context, review FrigateReview, reviewKey string, labels []string) (bool, error) {
+	allowed := false
+	for _, label := range labels {
+		// Cada label do review é um "evento" próprio no cooldown, para ser contabilizada no resumo
+		event := reviewEvent(review, label)
+		event.After.ID = fmt.Sprintf("%s-%s", review.After.ID, label)
+		ok, err := h.checkCooldown(ctx, event)
+		if err != nil {
+			return false, err
+		}
+		allowed = allowed || ok
+	}
+	if !allowed {
+		if err := h.redis.MarkEventAsProcessed(ctx, reviewKey, "suppressed"); err != nil {
+			return false, err
+		}
+	}
+	return allowed, nil
+}
+
+// reviewScore retorna o top_score do primeiro objeto do review, usado para decidir se a foto deve ser
+// substituída. Retorna 0 quando a edição de snapshots está desativada ou o evento não é encontrado.
+func (h *AppHandler) reviewScore(ctx context.Context, review FrigateReview) float64 {
+	if !h.cfg.EditSnapshots || len(review.After.Data.Detections) == 0 {
+		return 0
+	}
+	event, err := h.frigate.GetEvent(ctx, review.After.Data.Detections[0])
+	if err != nil {
+		log.Printf("Erro ao buscar score do review %s: %v", review.After.ID, err)
+		return 0
+	}
+	return event.Data.TopScore
+}
+
+// saveReviewNotification guarda no Redis a mensagem enviada para o review, para permitir editá-la depois
+func (h *AppHandler) saveReviewNotification(review FrigateReview, reviewKey string, msg telegram_handler.SentMessage, caption string, album bool, topScore float64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notification := redis_handler.EventNotification{
+		ChatID:    msg.ChatID,
+		MessageID: msg.MessageID,
+		ThreadID:  msg.ThreadID,
+		TopScore:  topScore,
+		Caption:   caption,
+		Album:     album,
+		Camera:    review.After.Camera,
+		Actions:   msg.EventID != "",
+	}
+	if err := h.redis.SaveEventNotification(ctx, reviewKey, notification); err != nil {
+		log.Printf("Erro ao salvar notificação do review %s no Redis: %v", review.After.ID, err)
+	}
+}
+
+// updateReviewNotification atualiza a notificação do review: a legenda quando novos objetos são
+// adicionados a ele e, com edit_snapshots, a foto quando o primeiro objeto tem um snapshot melhor.
+// Em álbuns a legenda é compartilhada entre os eventos, então a notificação não é editada.
+func (h *AppHandler) updateReviewNotification(ctx context.Context, review FrigateReview, reviewKey string) {
+	notification, err := h.redis.GetEventNotification(ctx, reviewKey)
+	if err != nil || notification == nil || notification.Album {
+		return
+	}
+
+	labels, _, _ := h.decideReview(review, rules.ActionPhoto)
+	if len(labels) == 0 {
+		return
+	}
+	caption := h.buildReviewCaption("🖼️", review, labels)
+
+	topScore := h.reviewScore(ctx, review)
+	betterScore := h.cfg.EditSnapshots && topScore > 0 && topScore >= notification.TopScore+h.cfg.EditMinScoreGain
+	if caption == notification.Caption && !betterScore {
+		return
+	}
+
+	// Os botões da notificação pertencem ao primeiro objeto rastreado, não ao review
+	eventID := ""
+	if len(review.After.Data.Detections) > 0 {
+		eventID = review.After.Data.Detections[0]
+	}
+	msg := notificationMessage(eventID, notification)
+
+	editCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if betterScore {
+		imgBytes, err := h.fetchSnapshot(ctx, eventID)
+		if err != nil {
+			log.Printf("Erro ao buscar snapshot do review %s: %v", review.After.ID, err)
+			return
+		}
+		if err := h.tgBot.EditPhoto(editCtx, msg, imgBytes, caption); err != nil {
+			log.Printf("Erro ao atualizar foto do review %s no Telegram: %v", review.After.ID, err)
+			return
+		}
+		log.Printf("Foto do review %s atualizada no Telegram (score %.0f%% -> %.0f%%).", review.After.ID, notification.TopScore*100, topScore*100)
+		notification.TopScore = topScore
+	} else if err := h.tgBot.EditCaption(editCtx, msg, caption); err != nil {
+		log.Printf("Erro ao atualizar legenda do review %s: %v", review.After.ID, err)
+		return
+	}
+
+	notification.Caption = caption
+	if err := h.redis.SaveEventNotification(ctx, reviewKey, *notification); err != nil {
+		log.Printf("Erro ao salvar notificação do review %s no Redis: %v", review.After.ID, err)
+	}
+}
+
+// processReviewPreview baixa a prévia do review pelo Frigate (com retry) e envia como vídeo
+func (h *AppHandler) processReviewPreview(review FrigateReview, labels []string, opts telegram_handler.SendOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var preview []byte
+	var err error
+	for attempt := 1; attempt <= 5; attempt++ {
+		if preview, err = h.frigate.GetReviewPreview(ctx, review.After.ID); err == nil && len(preview) > 0 {
+			break
+		}
+		log.Printf("Tentativa %d de 5 de baixar a prévia do review %s: %v", attempt, review.After.ID, err)
+		time.Sleep(3 * time.Second)
+	}
+	if err != nil || len(preview) == 0 {
+		log.Printf("Erro ao baixar prévia do review %s: %v", review.After.ID, err)
+		return
+	}
+
+	caption := h.buildReviewCaption("🎬", review, labels)
+	if err := h.tgBot.SendVideo(ctx, preview, caption, review.After.Camera, opts); err != nil {
+		log.Printf("Erro ao enviar prévia do review %s: %v", review.After.ID, err)
+		return
+	}
+	log.Printf("Prévia do review %s enviada para o Telegram.", review.After.ID)
+}
@@ -0,0 +1,193 @@
+package rules
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+)
+
+// Ações possíveis de uma regra
+const (
+	ActionPhoto  = "photo"  // envia a foto do evento
+	ActionClip   = "clip"   // envia o clipe no fim do evento
+	ActionSilent = "silent" // envia sem som de notificação (sozinha, implica photo)
+	ActionDrop   = "drop"   // não envia nada
+)
+
+// Event contém os campos do evento disponíveis nas expressões das regras
+type Event struct {
+	Type     string   `expr:"type"`      // new, update ou end
+	Camera   string   `expr:"camera"`    // nome da câmera
+	Label    string   `expr:"label"`     // label do objeto (person, car...)
+	SubLabel string   `expr:"sub_label"` // rosto ou sub label
+	Plate    string   `expr:"plate"`     // placa reconhecida
+	Zones    []string `expr:"zones"`     // zonas em que o objeto entrou
+	Score    float64  `expr:"score"`     // melhor score do objeto (0 a 1)
+	Hour     int      `expr:"hour"`      // hora de início do evento (0 a 23)
+	Minute   int      `expr:"minute"`    // minuto de início do evento
+	Time     string   `expr:"time"`      // horário de início no formato HH:MM
+	Weekday  string   `expr:"weekday"`   // dia da semana (mon..sun)
+}
+
+// Decision é o resultado da avaliação das regras para um evento
+type Decision struct {
+	Rule     string // nome da regra que correspondeu
+	Matched  bool   // false quando nenhuma regra correspondeu (comportamento padrão)
+	Drop     bool
+	Photo    bool
+	Clip     bool
+	Silent   bool
+	ChatID   int64
+	ThreadID int64
+}
+
+// Trace registra o resultado da avaliação de uma regra, usado no dry-run
+type Trace struct {
+	Rule    string
+	When    string
+	Matched bool
+	Err     error
+}
+
+type compiledRule struct {
+	rule    config.Rule
+	program *vm.Program
+}
+
+// Engine avalia as regras em ordem; a primeira que corresponder define a decisão
+type Engine struct {
+	rules  []compiledRule
+	failed sync.Map // regras com erro de execução já registrado no log
+}
+
+// NewEngine compila as expressões das regras e valida as ações
+func NewEngine(rules []config.Rule) (*Engine, error) {
+	engine := &Engine{}
+	for i, rule := range rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("regra %d", i+1)
+		}
+
+		for _, action := range rule.Actions {
+			switch action {
+			case ActionPhoto, ActionClip, ActionSilent, ActionDrop:
+			default:
+				return nil, fmt.Errorf("%s: ação inválida '%s'", rule.Name, action)
+			}
+		}
+
+		when := strings.TrimSpace(rule.When)
+		if when == "" {
+			when = "true"
+		}
+		program, err := expr.Compile(when, expr.Env(Event{}), expr.AsBool())
+		if err != nil {
+			return nil, fmt.Errorf("%s: erro ao compilar expressão: %w", rule.Name, err)
+		}
+
+		engine.rules = append(engine.rules, compiledRule{rule: rule, program: program})
+	}
+	return engine, nil
+}
+
+// Len retorna a quantidade de regras configuradas
+func (e *Engine) Len() int {
+	return len(e.rules)
+}
+
+// Evaluate retorna a decisão da primeira regra que corresponder ao evento.
+// As regras com erro de execução são ignoradas; o erro é registrado no log na primeira ocorrência de cada regra.
+func (e *Engine) Evaluate(event Event) Decision {
+	decision, traces := e.Explain(event)
+	for _, trace := range traces {
+		if trace.Err == nil {
+			continue
+		}
+		if _, logged := e.failed.LoadOrStore(trace.Rule, true); !logged {
+			log.Printf("Erro ao avaliar a regra '%s', ignorando: %v", trace.Rule, trace.Err)
+		}
+	}
+	return decision
+}
+
+// Explain avalia as regras em ordem e retorna, além da decisão, o resultado de cada regra avaliada
+func (e *Engine) Explain(event Event) (Decision, []Trace) {
+	var traces []Trace
+	for _, r := range e.rules {
+		output, err := expr.Run(r.program, event)
+		matched, _ := output.(bool)
+		traces = append(traces, Trace{Rule: r.rule.Name, When: r.rule.When, Matched: err == nil && matched, Err: err})
+		if err != nil || !matched {
+			continue
+		}
+		return decisionFor(r.rule), traces
+	}
+
+	// Sem regra correspondente: foto e clipe, como sem regras
+	return Decision{Photo: true, Clip: true}, traces
+}
+
+// decisionFor converte as ações da regra em uma decisão
+func decisionFor(rule config.Rule) Decision {
+	decision := Decision{
+		Rule:     rule.Name,
+		Matched:  true,
+		ChatID:   rule.ChatID,
+		ThreadID: rule.ThreadID,
+	}
+	for _, action := range rule.Actions {
+		switch action {
+		case ActionPhoto:
+			decision.Photo = true
+		case ActionClip:
+			decision.Clip = true
+		case ActionSilent:
+			decision.Silent = true
+		case ActionDrop:
+			decision.Drop = true
+		}
+	}
+	// "silent" sozinha é uma notificação silenciosa, não um descarte
+	if decision.Silent && !decision.Photo && !decision.Clip {
+		decision.Photo = true
+	}
+	return decision
+}
+
+// String descreve a decisão de forma legível
+func (d Decision) String() string {
+	if !d.Matched {
+		return "nenhuma regra correspondeu: comportamento padrão (foto e clipe, sujeito aos filtros)"
+	}
+	if d.Drop {
+		return fmt.Sprintf("regra '%s': descartar", d.Rule)
+	}
+
+	var actions []string
+	if d.Photo {
+		actions = append(actions, "foto")
+	}
+	if d.Clip {
+		actions = append(actions, "clipe")
+	}
+	if d.Silent {
+		actions = append(actions, "silencioso")
+	}
+	if len(actions) == 0 {
+		actions = append(actions, "nenhuma ação")
+	}
+	result := fmt.Sprintf("regra '%s': %s", d.Rule, strings.Join(actions, ", "))
+	if d.ChatID != 0 {
+		result += fmt.Sprintf(", chat %d", d.ChatID)
+	}
+	if d.ThreadID != 0 {
+		result += fmt.Sprintf(", thread %d", d.ThreadID)
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+)
+
+func TestNewEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []config.Rule
+		wantErr bool
+	}{
+		{"sem regras", nil, false},
+		{"regra válida", []config.Rule{{When: `label == "person"`, Actions: []string{ActionPhoto, ActionClip}}}, false},
+		{"when vazio", []config.Rule{{Actions: []string{ActionDrop}}}, false},
+		{"ação inválida", []config.Rule{{When: "true", Actions: []string{"video"}}}, true},
+		{"expressão inválida", []config.Rule{{When: `label ==`, Actions: []string{ActionPhoto}}}, true},
+		{"campo inexistente", []config.Rule{{When: `color == "red"`, Actions: []string{ActionPhoto}}}, true},
+		{"expressão não booleana", []config.Rule{{When: `score`, Actions: []string{ActionPhoto}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewEngine(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEngine() erro = %v, esperado erro = %v", err, tt.wantErr)
+			}
+			if err == nil && engine.Len() != len(tt.rules) {
+				t.Errorf("Len() = %d, esperado %d", engine.Len(), len(tt.rules))
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	engine, err := NewEngine([]config.Rule{
+		{Name: "quebrada", When: `zones[5] == "portao"`, Actions: []string{ActionDrop}},
+		{Name: "carro-noite", When: `label == "car" && (hour >= 22 || hour < 6)`, Actions: []string{ActionPhoto, ActionClip}, ThreadID: 10},
+		{Name: "pessoa-silenciosa", When: `label == "person" && "portao" in zones`, Actions: []string{ActionSilent}},
+		{When: `label == "dog"`, Actions: []string{ActionDrop}},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine() erro = %v", err)
+	}
+
+	portao := []string{"portao", "a", "b", "c", "d", "e"}
+	tests := []struct {
+		name       string
+		event      Event
+		want       Decision
+		wantTraces int
+		wantErrAt  int // índice do trace com erro (-1 = nenhum)
+	}{
+		{
+			name:       "erro de execução é ignorado",
+			event:      Event{Label: "car", Hour: 23},
+			want:       Decision{Rule: "carro-noite", Matched: true, Photo: true, Clip: true, ThreadID: 10},
+			wantTraces: 2,
+			wantErrAt:  0,
+		},
+		{
+			name:       "silent sozinha envia a foto",
+			event:      Event{Label: "person", Zones: portao},
+			want:       Decision{Rule: "pessoa-silenciosa", Matched: true, Photo: true, Silent: true},
+			wantTraces: 3,
+			wantErrAt:  -1,
+		},
+		{
+			name:       "nome padrão da regra",
+			event:      Event{Label: "dog", Zones: portao},
+			want:       Decision{Rule: "regra 4", Matched: true, Drop: true},
+			wantTraces: 4,
+			wantErrAt:  -1,
+		},
+		{
+			name:       "nenhuma regra corresponde",
+			event:      Event{Label: "car", Hour: 12, Zones: portao},
+			want:       Decision{Photo: true, Clip: true},
+			wantTraces: 4,
+			wantErrAt:  -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, traces := engine.Explain(tt.event)
+			if got != tt.want {
+				t.Errorf("Explain() = %+v, esperado %+v", got, tt.want)
+			}
+			if len(traces) != tt.wantTraces {
+				t.Fatalf("Explain() retornou %d traces, esperado %d", len(traces), tt.wantTraces)
+			}
+			for i, trace := range traces {
+				if (trace.Err != nil) != (i == tt.wantErrAt) {
+					t.Errorf("trace %d (%s): erro = %v", i, trace.Rule, trace.Err)
+				}
+			}
+			if tt.want.Matched && !traces[len(traces)-1].Matched {
+				t.Errorf("último trace deveria ter correspondido: %+v", traces[len(traces)-1])
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// storageMonitor acompanha o uso do disco de gravações do Frigate, alertando quando os limites
+// configurados são ultrapassados e enviando um resumo semanal por câmera
+type storageMonitor struct {
+	h *AppHandler
+}
+
+// newStorageMonitor cria um novo monitor de armazenamento
+func newStorageMonitor(h *AppHandler) *storageMonitor {
+	return &storageMonitor{h: h}
+}
+
+// run consulta periodicamente o uso de armazenamento
+func (m *storageMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.h.cfg.Storage.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check verifica os limites de uso do disco e se o resumo semanal está pendente
+func (m *storageMonitor) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	stats, err := m.h.frigate.GetStats(checkCtx)
+	if err != nil {
+		log.Printf("Erro ao consultar estatísticas do Frigate: %v", err)
+		return
+	}
+	disk, ok := stats.Service.Storage[m.h.cfg.Storage.Path]
+	if !ok || disk.Total <= 0 {
+		log.Printf("Aviso: armazenamento '%s' não encontrado nas estatísticas do Frigate", m.h.cfg.Storage.Path)
+		return
+	}
+	percent := disk.Used / disk.Total * 100
+
+	if err := m.checkThresholds(checkCtx, percent, disk.Used, disk.Total); err != nil {
+		log.Printf("Erro ao verificar limites de armazenamento: %v", err)
+	}
+	if err := m.checkSummary(checkCtx, percent, disk.Used, disk.Total); err != nil {
+		log.Printf("Erro ao enviar resumo de armazenamento: %v", err)
+	}
+}
+
+// checkThresholds alerta quando o uso passa de um novo limite. O último limite alertado fica no Redis,
+// então o mesmo alerta não é repetido; quando o uso cai, o nível é reduzido para alertar novamente
+func (m *storageMonitor) checkThresholds(ctx context.Context, percent, used, total float64) error {
+	level := 0.0
+	for _, threshold := range m.h.cfg.Storage.Thresholds {
+		if percent >= threshold {
+			level = threshold
+		}
+	}
+
+	alerted, err := m.h.redis.GetStorageAlertLevel(ctx)
+	if err != nil {
+		return err
+	}
+	if level == alerted {
+		return nil
+	}
+
+	if level > alerted {
+		message := fmt.Sprintf("⚠️ Disco de gravações do Frigate com %.0f%% de uso (%.1f / %.1f GB), acima do limite de %.0f%%",
+			percent, used/1024, total/1024, level)
+		if err := m.h.tgBot.SendMessage(ctx, message, "General"); err != nil {
+			return err
+		}
+		log.Printf("Alerta de armazenamento enviado: %.0f%% de uso.", percent)
+	}
+	return m.h.redis.SetStorageAlertLevel(ctx, level)
+}
+
+// checkSummary envia o resumo semanal de armazenamento por câmera, uma vez por semana
+func (m *storageMonitor) checkSummary(ctx context.Context, percent, used, total float64) error {
+	now := m.h.localTime(time.Now())
+	if !m.h.cfg.Storage.SummaryDue(now) {
+		return nil
+	}
+
+	year, week := now.ISOWeek()
+	current := fmt.Sprintf("%d-%02d", year, week)
+	last, err := m.h.redis.GetLastStorageSummary(ctx)
+	if err != nil {
+		return err
+	}
+	if last == current {
+		return nil
+	}
+
+	cameras, err := m.h.frigate.GetRecordingsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cameras))
+	for name := range cameras {
+		names = append(names, name)
+	}
+	// Câmeras que mais ocupam espaço primeiro
+	sort.Slice(names, func(i, j int) bool { return cameras[names[i]].Usage > cameras[names[j]].Usage })
+
+	lines := []string{
+		"💾 Resumo semanal de armazenamento",
+		fmt.Sprintf("Disco: %.1f / %.1f GB (%.0f%%)", used/1024, total/1024, percent),
+		"",
+	}
+	for _, name := range names {
+		camera := cameras[name]
+		lines = append(lines, fmt.Sprintf("• %s: %.1f GB (%.0f%%) | %.2f GB/dia", name, camera.Usage/1024, camera.UsagePercent, camera.Bandwidth*24/1024))
+	}
+
+	if err := m.h.tgBot.SendMessage(ctx, strings.Join(lines, "\n"), "General"); err != nil {
+		return err
+	}
+	log.Println("Resumo semanal de armazenamento enviado.")
+	return m.h.redis.SetLastStorageSummary(ctx, current)
+}
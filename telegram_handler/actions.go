@@ -0,0 +1,158 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// actionCallbackPrefix identifica os botões das notificações ("act:<ação>:<evento>"). A câmera não
+// vai no callback por causa do limite de 64 bytes do Telegram; ela é buscada pelo evento quando necessário.
+const actionCallbackPrefix = "act:"
+
+// muteButtonDuration é o tempo que a câmera fica silenciada pelo botão da notificação
+const muteButtonDuration = time.Hour
+
+// Ações disponíveis nos botões das notificações
+const (
+	actionClip          = "clip"
+	actionMute          = "mute"
+	actionFalsePositive = "fp"
+	actionRetain        = "retain"
+)
+
+// eventAction descreve um botão da notificação
+type eventAction struct {
+	name  string
+	label string
+}
+
+// eventActionRows define a disposição dos botões na notificação
+var eventActionRows = [][]eventAction{
+	{{actionClip, "🎬 Clipe"}, {actionMute, "🔕 Silenciar 1h"}},
+	{{actionFalsePositive, "🚫 Falso positivo"}, {actionRetain, "📌 Reter"}},
+}
+
+// eventKeyboard monta o teclado de ações de um evento. Os botões já usados mostram quem os pressionou.
+func (b *TelegramBot) eventKeyboard(ctx context.Context, eventID string) *models.InlineKeyboardMarkup {
+	pressed, err := b.Redis.GetEventActions(ctx, eventID)
+	if err != nil {
+		log.Printf("Erro ao buscar ações do evento %s: %v", eventID, err)
+	}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(eventActionRows))
+	for _, actions := range eventActionRows {
+		row := make([]models.InlineKeyboardButton, 0, len(actions))
+		for _, action := range actions {
+			text := action.label
+			if user, ok := pressed[action.name]; ok {
+				text = fmt.Sprintf("✅ %s (%s)", action.label, user)
+			}
+			row = append(row, models.InlineKeyboardButton{
+				Text:         text,
+				CallbackData: fmt.Sprintf("%s%s:%s", actionCallbackPrefix, action.name, eventID),
+			})
+		}
+		rows = append(rows, row)
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// userName retorna o nome exibido de quem pressionou um botão
+func userName(user models.User) string {
+	if user.Username != "" {
+		return "@" + user.Username
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+// eventCamera retorna a câmera de um evento, pela notificação guardada no Redis ou, se ela não
+// existir (ex.: notificações de reviews), pelo próprio Frigate
+func (b *TelegramBot) eventCamera(ctx context.Context, eventID string) (string, error) {
+	notification, err := b.Redis.GetEventNotification(ctx, eventID)
+	if err != nil {
+		log.Printf("Erro ao buscar notificação do evento %s: %v", eventID, err)
+	}
+	if notification != nil && notification.Camera != "" {
+		return notification.Camera, nil
+	}
+
+	event, err := b.Frigate.GetEvent(ctx, eventID)
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar câmera do evento: %w", err)
+	}
+	return event.Camera, nil
+}
+
+// handleActionCallback executa a ação de um botão da notificação e atualiza o teclado
+func (b *TelegramBot) handleActionCallback(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	query := update.CallbackQuery
+	message := query.Message.Message
+
+	parts := strings.Split(strings.TrimPrefix(query.Data, actionCallbackPrefix), ":")
+	if message == nil || len(parts) != 2 {
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+		return
+	}
+	action, eventID := parts[0], parts[1]
+	user := userName(query.From)
+
+	// Apenas o clipe pode ser pedido por viewers; as demais ações alteram o bot ou o Frigate
+	if action != actionClip && !b.requireAdmin(ctx, bot, update) {
+		return
+	}
+
+	var result string
+	var err error
+	switch action {
+	case actionClip:
+		result = "🎬 Buscando clipe..."
+	case actionMute:
+		var cameraName string
+		if cameraName, err = b.eventCamera(ctx, eventID); err == nil {
+			err = b.Redis.MuteCamera(ctx, cameraName, muteButtonDuration)
+		}
+		result = fmt.Sprintf("🔕 Câmera %s silenciada por %s", cameraName, FormatDuration(muteButtonDuration))
+	case actionFalsePositive:
+		err = b.Frigate.MarkFalsePositive(ctx, eventID)
+		result = "🚫 Evento marcado como falso positivo"
+	case actionRetain:
+		err = b.Frigate.RetainEvent(ctx, eventID)
+		result = "📌 Evento retido no Frigate"
+	default:
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+		return
+	}
+
+	if err != nil {
+		log.Printf("Erro ao executar ação '%s' do evento %s: %v", action, eventID, err)
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{
+			CallbackQueryID: query.ID,
+			Text:            fmt.Sprintf("Erro: %v", err),
+			ShowAlert:       true,
+		})
+		return
+	}
+	bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID, Text: result})
+	log.Printf("Ação '%s' do evento %s executada por %s", action, eventID, user)
+
+	if err := b.Redis.SaveEventAction(ctx, eventID, action, user); err != nil {
+		log.Printf("Erro ao salvar ação do evento %s: %v", eventID, err)
+	}
+	if _, err := bot.EditMessageReplyMarkup(ctx, &tgbotapi.EditMessageReplyMarkupParams{
+		ChatID:      message.Chat.ID,
+		MessageID:   message.ID,
+		ReplyMarkup: b.eventKeyboard(ctx, eventID),
+	}); err != nil {
+		log.Printf("Erro ao atualizar botões do evento %s: %v", eventID, err)
+	}
+
+	if action == actionClip {
+		b.sendEventClip(ctx, eventID, message.Chat.ID, message.MessageThreadID, message.ID)
+	}
+}
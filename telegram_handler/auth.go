@@ -0,0 +1,146 @@
+package telegram_handler
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// role é o nível de acesso de um usuário aos comandos do bot
+type role int
+
+const (
+	roleNone role = iota
+	roleViewer
+	roleAdmin
+)
+
+// updateSender retorna o chat e o usuário de um update (mensagem ou botão).
+// Retorna false para os tipos de update que o bot não trata.
+func updateSender(update *models.Update) (models.Chat, *models.User, bool) {
+	switch {
+	case update.Message != nil:
+		return update.Message.Chat, update.Message.From, true
+	case update.CallbackQuery != nil:
+		from := update.CallbackQuery.From
+		if msg := update.CallbackQuery.Message.Message; msg != nil {
+			return msg.Chat, &from, true
+		}
+		if msg := update.CallbackQuery.Message.InaccessibleMessage; msg != nil {
+			return msg.Chat, &from, true
+		}
+		return models.Chat{}, &from, true
+	}
+	return models.Chat{}, nil, false
+}
+
+// chatAllowed verifica se o bot atende o chat: o chat padrão, os chats configurados
+// ou a conversa privada com um usuário autorizado
+func (b *TelegramBot) chatAllowed(chat models.Chat, user *models.User) bool {
+	if chat.ID == b.DefaultChatID || slices.Contains(b.Auth.Chats, chat.ID) {
+		return true
+	}
+	if chat.Type == models.ChatTypePrivate && user != nil {
+		return slices.Contains(b.Auth.Admins, user.ID) || slices.Contains(b.Auth.Viewers, user.ID)
+	}
+	return false
+}
+
+// roleFor retorna o nível de acesso do usuário no chat
+func (b *TelegramBot) roleFor(chat models.Chat, user *models.User) role {
+	if !b.chatAllowed(chat, user) {
+		return roleNone
+	}
+	// Sem admins configurados, todos os usuários do chat são apenas viewers: os comandos que
+	// alteram o estado do bot ou do Frigate ficam bloqueados até que um admin seja configurado
+	if len(b.Auth.Admins) == 0 {
+		return roleViewer
+	}
+	if user == nil {
+		return roleNone
+	}
+	if slices.Contains(b.Auth.Admins, user.ID) {
+		return roleAdmin
+	}
+	if slices.Contains(b.Auth.Viewers, user.ID) {
+		return roleViewer
+	}
+	return roleNone
+}
+
+// logDenied registra uma tentativa de acesso negada
+func logDenied(chat models.Chat, user *models.User, update *models.Update, reason string) {
+	userID, name := int64(0), ""
+	if user != nil {
+		userID, name = user.ID, userName(*user)
+	}
+	text := ""
+	if update.Message != nil {
+		text = update.Message.Text
+	} else if update.CallbackQuery != nil {
+		text = update.CallbackQuery.Data
+	}
+	log.Printf("Acesso negado (%s): usuário %d (%s) no chat %d: %q", reason, userID, name, chat.ID, text)
+}
+
+// authMiddleware ignora os updates de chats desconhecidos e de usuários não autorizados
+func (b *TelegramBot) authMiddleware(next tgbotapi.HandlerFunc) tgbotapi.HandlerFunc {
+	return func(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+		chat, user, ok := updateSender(update)
+		if !ok {
+			next(ctx, bot, update)
+			return
+		}
+
+		if !b.chatAllowed(chat, user) {
+			logDenied(chat, user, update, "chat desconhecido")
+			return
+		}
+		if b.roleFor(chat, user) == roleNone {
+			logDenied(chat, user, update, "usuário não autorizado")
+			if update.CallbackQuery != nil {
+				bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{
+					CallbackQueryID: update.CallbackQuery.ID,
+					Text:            "⛔ Você não tem permissão para usar o bot",
+					ShowAlert:       true,
+				})
+			}
+			return
+		}
+
+		next(ctx, bot, update)
+	}
+}
+
+// adminOnly restringe um handler aos administradores
+func (b *TelegramBot) adminOnly(next tgbotapi.HandlerFunc) tgbotapi.HandlerFunc {
+	return func(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+		if !b.requireAdmin(ctx, bot, update) {
+			return
+		}
+		next(ctx, bot, update)
+	}
+}
+
+// requireAdmin verifica se quem enviou o update é administrador, avisando o usuário quando não for
+func (b *TelegramBot) requireAdmin(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) bool {
+	chat, user, _ := updateSender(update)
+	if b.roleFor(chat, user) == roleAdmin {
+		return true
+	}
+
+	logDenied(chat, user, update, "restrito a administradores")
+	if update.Message != nil {
+		bot.SendMessage(ctx, stringToMessage("⛔ Comando restrito a administradores", chat.ID, &update.Message.MessageThreadID))
+	} else if update.CallbackQuery != nil {
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{
+			CallbackQueryID: update.CallbackQuery.ID,
+			Text:            "⛔ Ação restrita a administradores",
+			ShowAlert:       true,
+		})
+	}
+	return false
+}
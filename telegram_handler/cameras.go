@@ -0,0 +1,234 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// cameraCallbackPrefix identifica os callbacks do seletor de câmeras ("cam:<ação>:<câmera>")
+const cameraCallbackPrefix = "cam:"
+
+// commandArgs retorna os argumentos de um comando (ex.: "/snapshot Portao" -> ["Portao"])
+func commandArgs(text string) []string {
+	fields := strings.Fields(text)
+	if len(fields) <= 1 {
+		return nil
+	}
+	return fields[1:]
+}
+
+// cameraNames retorna as câmeras configuradas nos grupos, ou as câmeras do Frigate quando não há grupos
+func (b *TelegramBot) cameraNames(ctx context.Context) []string {
+	var names []string
+	for _, group := range b.Groups {
+		if group.Name != "General" {
+			names = append(names, group.Name)
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+
+	stats, err := b.Frigate.GetStats(ctx)
+	if err != nil {
+		log.Printf("Erro ao buscar câmeras do Frigate: %v", err)
+		return nil
+	}
+	return sortedKeys(stats.Cameras)
+}
+
+// findCamera procura uma câmera pelo nome, sem diferenciar maiúsculas/minúsculas
+func (b *TelegramBot) findCamera(ctx context.Context, name string) string {
+	for _, camera := range b.cameraNames(ctx) {
+		if strings.EqualFold(camera, name) {
+			return camera
+		}
+	}
+	return ""
+}
+
+// resolveCamera define a câmera do comando: pelo argumento informado ou pela thread da mensagem.
+// Retorna "" quando nenhuma câmera pode ser definida e false quando o argumento é inválido
+// (nesse caso o usuário já foi avisado).
+func (b *TelegramBot) resolveCamera(ctx context.Context, message *models.Message, args []string) (string, bool) {
+	if len(args) > 0 {
+		cameraName := b.findCamera(ctx, args[0])
+		if cameraName == "" {
+			b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Câmera '%s' não encontrada", args[0]), message.Chat.ID, &message.MessageThreadID))
+			return "", false
+		}
+		return cameraName, true
+	}
+	// A thread General não é de nenhuma câmera
+	cameraName := b.getCameraName(int64(message.MessageThreadID))
+	if cameraName == "General" {
+		return "", true
+	}
+	return cameraName, true
+}
+
+// sendCameraPicker envia um teclado inline com as câmeras para o usuário escolher, como resposta
+// ao comando (replyTo). A ação é repassada no callback (ex.: "snapshot" ou "record:10").
+func (b *TelegramBot) sendCameraPicker(ctx context.Context, chatID int64, threadID int, replyTo int, action string) {
+	cameras := b.cameraNames(ctx)
+	if len(cameras) == 0 {
+		b.Bot.SendMessage(ctx, stringToMessage("Nenhuma câmera configurada", chatID, &threadID))
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for i, camera := range cameras {
+		button := models.InlineKeyboardButton{
+			Text:         camera,
+			CallbackData: fmt.Sprintf("%s%s:%s", cameraCallbackPrefix, action, camera),
+		}
+		if i%2 == 0 {
+			rows = append(rows, []models.InlineKeyboardButton{button})
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], button)
+		}
+	}
+
+	message := stringToMessage("📷 Escolha a câmera:", chatID, &threadID)
+	message.ReplyMarkup = &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+	message.ReplyParameters = SendOptions{ReplyTo: replyTo}.replyParameters()
+	if _, err := b.Bot.SendMessage(ctx, message); err != nil {
+		log.Printf("Erro ao enviar seletor de câmeras: %v", err)
+	}
+}
+
+// handleCameraCallback executa a ação escolhida no seletor de câmeras
+func (b *TelegramBot) handleCameraCallback(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	query := update.CallbackQuery
+
+	// A gravação cria um evento no Frigate, então é restrita aos administradores como o /record
+	if strings.HasPrefix(strings.TrimPrefix(query.Data, cameraCallbackPrefix), "record:") && !b.requireAdmin(ctx, bot, update) {
+		return
+	}
+	bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+	message := query.Message.Message
+	if message == nil {
+		return
+	}
+
+	// Formatos: "cam:snapshot:<câmera>" e "cam:record:<segundos>:<câmera>"
+	parts := strings.SplitN(strings.TrimPrefix(query.Data, cameraCallbackPrefix), ":", 2)
+	if len(parts) != 2 {
+		return
+	}
+	action, rest := parts[0], parts[1]
+
+	duration := 0
+	if action == "record" {
+		recordParts := strings.SplitN(rest, ":", 2)
+		if len(recordParts) != 2 {
+			return
+		}
+		var err error
+		if duration, err = strconv.Atoi(recordParts[0]); err != nil {
+			return
+		}
+		rest = recordParts[1]
+	}
+
+	cameraName := b.findCamera(ctx, rest)
+	if cameraName == "" {
+		return
+	}
+
+	// Substitui o seletor pela câmera escolhida
+	bot.EditMessageText(ctx, &tgbotapi.EditMessageTextParams{
+		ChatID:    message.Chat.ID,
+		MessageID: message.ID,
+		Text:      fmt.Sprintf("📷 Câmera selecionada: %s", cameraName),
+	})
+
+	switch action {
+	case "snapshot":
+		b.sendSnapshot(ctx, cameraName, message.Chat.ID, message.MessageThreadID)
+	case "record":
+		// O clipe responde ao comando /record original, ao qual o seletor responde
+		replyTo := message.ID
+		if message.ReplyToMessage != nil {
+			replyTo = message.ReplyToMessage.ID
+		}
+		b.createRecording(ctx, cameraName, duration, message.Chat.ID, message.MessageThreadID, replyTo)
+	}
+}
+
+func (b *TelegramBot) handleCameras(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	b.sendAllSnapshots(ctx, update.Message.Chat.ID, update.Message.MessageThreadID)
+}
+
+// cameraSnapshot é o resultado da busca do snapshot de uma câmera
+type cameraSnapshot struct {
+	camera string
+	image  []byte
+	err    error
+}
+
+// sendAllSnapshots busca o snapshot de todas as câmeras em paralelo e envia em álbuns de até 10 fotos.
+// As câmeras que falharem são listadas em uma mensagem ao final.
+func (b *TelegramBot) sendAllSnapshots(ctx context.Context, chatID int64, threadID int) {
+	cameras := b.cameraNames(ctx)
+	if len(cameras) == 0 {
+		b.Bot.SendMessage(ctx, stringToMessage("Nenhuma câmera configurada", chatID, &threadID))
+		return
+	}
+
+	results := make([]cameraSnapshot, len(cameras))
+	var wg sync.WaitGroup
+	for i, camera := range cameras {
+		wg.Add(1)
+		go func(i int, camera string) {
+			defer wg.Done()
+			snapshotCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			defer cancel()
+			image, err := b.Frigate.GetSnapshot(snapshotCtx, camera)
+			results[i] = cameraSnapshot{camera: camera, image: image, err: err}
+		}(i, camera)
+	}
+	wg.Wait()
+
+	var ok []cameraSnapshot
+	var failed []string
+	for _, result := range results {
+		if result.err != nil || len(result.image) == 0 {
+			log.Printf("Erro ao obter snapshot da câmera %s: %v", result.camera, result.err)
+			failed = append(failed, result.camera)
+			continue
+		}
+		ok = append(ok, result)
+	}
+
+	opts := SendOptions{ChatID: chatID, ThreadID: int64(threadID)}
+	for start := 0; start < len(ok); start += MaxAlbumSize {
+		end := min(start+MaxAlbumSize, len(ok))
+
+		photos := make([][]byte, 0, end-start)
+		names := make([]string, 0, end-start)
+		for _, result := range ok[start:end] {
+			photos = append(photos, result.image)
+			names = append(names, result.camera)
+		}
+
+		caption := fmt.Sprintf("📸 Snapshots: %s", strings.Join(names, ", "))
+		if _, err := b.SendAlbum(ctx, photos, caption, "", opts); err != nil {
+			log.Printf("Erro ao enviar álbum de snapshots: %v", err)
+			failed = append(failed, names...)
+		}
+	}
+
+	if len(failed) > 0 {
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("⚠️ Não foi possível obter o snapshot de: %s", strings.Join(failed, ", ")), chatID, &threadID))
+	}
+}
@@ -0,0 +1,301 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+)
+
+const (
+	// eventsCallbackPrefix identifica os callbacks da lista de eventos:
+	// "ev:p:<página>:<horas>:<câmera>:<label>", "ev:s:<id>" (snapshot) e "ev:c:<id>" (clipe)
+	eventsCallbackPrefix = "ev:"
+	eventsPageSize       = 5
+	eventsDefaultHours   = 24
+	eventClipRetries     = 3
+)
+
+// eventsFilter são os filtros do comando /events, repassados nos botões de paginação
+type eventsFilter struct {
+	camera string
+	label  string
+	hours  int
+}
+
+func (b *TelegramBot) handleEvents(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+
+	// Aceita "/events [câmera] [label] [horas]"; sem câmera, usa a câmera da thread atual
+	args := commandArgs(update.Message.Text)
+	filter := eventsFilter{hours: eventsDefaultHours}
+	if len(args) > 0 {
+		if hours, err := strconv.Atoi(args[len(args)-1]); err == nil {
+			if hours <= 0 {
+				bot.SendMessage(ctx, stringToMessage("A quantidade de horas deve ser maior que zero", chatID, &threadID))
+				return
+			}
+			filter.hours = hours
+			args = args[:len(args)-1]
+		}
+	}
+	if len(args) > 2 {
+		bot.SendMessage(ctx, stringToMessage("Uso: /events [câmera] [label] [horas]", chatID, &threadID))
+		return
+	}
+	if len(args) > 0 {
+		// Um único argumento pode ser a câmera ou a label
+		if camera := b.findCamera(ctx, args[0]); camera != "" {
+			filter.camera = camera
+			args = args[1:]
+		} else if len(args) == 2 {
+			bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Câmera '%s' não encontrada", args[0]), chatID, &threadID))
+			return
+		}
+	}
+	if len(args) > 0 {
+		filter.label = strings.ToLower(args[0])
+	}
+	if filter.camera == "" {
+		filter.camera = b.getCameraName(int64(threadID))
+		if filter.camera == "General" {
+			filter.camera = ""
+		}
+	}
+
+	text, markup, err := b.eventsPage(ctx, filter, 0)
+	if err != nil {
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao buscar eventos: %v", err), chatID, &threadID))
+		return
+	}
+
+	message := stringToMessage(text, chatID, &threadID)
+	message.ReplyMarkup = markup
+	if _, err := bot.SendMessage(ctx, message); err != nil {
+		log.Printf("Erro ao enviar lista de eventos: %v", err)
+	}
+}
+
+// eventsPage monta o texto e os botões de uma página da lista de eventos
+func (b *TelegramBot) eventsPage(ctx context.Context, filter eventsFilter, page int) (string, *models.InlineKeyboardMarkup, error) {
+	// Busca um evento a mais que o necessário para saber se existe a próxima página
+	events, err := b.Frigate.GetEvents(ctx, frigate.EventsQuery{
+		Camera: filter.camera,
+		Label:  filter.label,
+		After:  float64(time.Now().Add(-time.Duration(filter.hours) * time.Hour).Unix()),
+		Limit:  (page+1)*eventsPageSize + 1,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	title := fmt.Sprintf("📋 Eventos das últimas %dh", filter.hours)
+	if filter.camera != "" {
+		title += fmt.Sprintf(" - 🎥 %s", filter.camera)
+	}
+	if filter.label != "" {
+		title += fmt.Sprintf(" - #%s", filter.label)
+	}
+
+	start := page * eventsPageSize
+	if start >= len(events) {
+		return title + "\n\nNenhum evento encontrado", nil, nil
+	}
+	end := min(start+eventsPageSize, len(events))
+	hasNext := len(events) > end
+
+	lines := []string{fmt.Sprintf("%s (página %d)", title, page+1), ""}
+	var rows [][]models.InlineKeyboardButton
+	for i, event := range events[start:end] {
+		number := start + i + 1
+		lines = append(lines, b.formatEventLine(number, event))
+
+		var row []models.InlineKeyboardButton
+		if event.HasSnapshot {
+			row = append(row, models.InlineKeyboardButton{
+				Text:         fmt.Sprintf("🖼️ %d", number),
+				CallbackData: eventsCallbackPrefix + "s:" + event.ID,
+			})
+		}
+		if event.HasClip && event.EndTime > 0 {
+			row = append(row, models.InlineKeyboardButton{
+				Text:         fmt.Sprintf("🎬 %d", number),
+				CallbackData: eventsCallbackPrefix + "c:" + event.ID,
+			})
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	var navigation []models.InlineKeyboardButton
+	if page > 0 {
+		navigation = append(navigation, models.InlineKeyboardButton{
+			Text:         "⬅️ Anterior",
+			CallbackData: eventsPageCallback(filter, page-1),
+		})
+	}
+	if hasNext {
+		navigation = append(navigation, models.InlineKeyboardButton{
+			Text:         "Próxima ➡️",
+			CallbackData: eventsPageCallback(filter, page+1),
+		})
+	}
+	if len(navigation) > 0 {
+		rows = append(rows, navigation)
+	}
+
+	return strings.Join(lines, "\n"), &models.InlineKeyboardMarkup{InlineKeyboard: rows}, nil
+}
+
+// formatEventLine formata um evento da lista
+func (b *TelegramBot) formatEventLine(number int, event frigate.Event) string {
+	line := fmt.Sprintf("%d. #%s 🎥 %s 🕒 %s", number, event.Label, event.Camera, b.localTime(event.StartTime).Format("02/01 15:04:05"))
+	if event.SubLabel != "" {
+		line += fmt.Sprintf(" 👤 %s", event.SubLabel)
+	}
+	score := event.Data.TopScore
+	if score == 0 {
+		score = event.Data.Score
+	}
+	if score > 0 {
+		line += fmt.Sprintf(" 📊 %.0f%%", score*100)
+	}
+	if event.EndTime == 0 {
+		line += " ⏳"
+	}
+	return line
+}
+
+func eventsPageCallback(filter eventsFilter, page int) string {
+	return fmt.Sprintf("%sp:%d:%d:%s:%s", eventsCallbackPrefix, page, filter.hours, filter.camera, filter.label)
+}
+
+// handleEventsCallback trata a paginação e os botões de snapshot/clipe da lista de eventos
+func (b *TelegramBot) handleEventsCallback(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	query := update.CallbackQuery
+	message := query.Message.Message
+	if message == nil {
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(query.Data, eventsCallbackPrefix), ":", 2)
+	if len(parts) != 2 {
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+		return
+	}
+
+	switch parts[0] {
+	case "p":
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+		b.changeEventsPage(ctx, message, parts[1])
+	case "s":
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID, Text: "🖼️ Buscando snapshot..."})
+		b.sendEventSnapshot(ctx, parts[1], message.Chat.ID, message.MessageThreadID, message.ID)
+	case "c":
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID, Text: "🎬 Buscando clipe..."})
+		b.sendEventClip(ctx, parts[1], message.Chat.ID, message.MessageThreadID, message.ID)
+	default:
+		bot.AnswerCallbackQuery(ctx, &tgbotapi.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+	}
+}
+
+// changeEventsPage substitui a lista de eventos pela página pedida ("<página>:<horas>:<câmera>:<label>")
+func (b *TelegramBot) changeEventsPage(ctx context.Context, message *models.Message, data string) {
+	parts := strings.SplitN(data, ":", 4)
+	if len(parts) != 4 {
+		return
+	}
+	page, err := strconv.Atoi(parts[0])
+	if err != nil || page < 0 {
+		return
+	}
+	hours, err := strconv.Atoi(parts[1])
+	if err != nil || hours <= 0 {
+		return
+	}
+
+	text, markup, err := b.eventsPage(ctx, eventsFilter{camera: parts[2], label: parts[3], hours: hours}, page)
+	if err != nil {
+		text = fmt.Sprintf("Erro ao buscar eventos: %v", err)
+	}
+
+	params := &tgbotapi.EditMessageTextParams{
+		ChatID:    message.Chat.ID,
+		MessageID: message.ID,
+		Text:      text,
+	}
+	if markup != nil {
+		params.ReplyMarkup = markup
+	}
+	if _, err := b.Bot.EditMessageText(ctx, params); err != nil {
+		log.Printf("Erro ao atualizar lista de eventos: %v", err)
+	}
+}
+
+// sendEventSnapshot envia o snapshot de um evento como resposta à mensagem informada
+func (b *TelegramBot) sendEventSnapshot(ctx context.Context, eventID string, chatID int64, threadID int, replyTo int) {
+	snapshotCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	snapshot, err := b.Frigate.GetEventSnapshot(snapshotCtx, eventID)
+	if err != nil {
+		log.Printf("Erro ao obter snapshot do evento %s: %v", eventID, err)
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao obter snapshot do evento: %v", err), chatID, &threadID))
+		return
+	}
+
+	opts := SendOptions{ChatID: chatID, ThreadID: int64(threadID), ReplyTo: replyTo}
+	if _, err := b.SendPhoto(snapshotCtx, snapshot, fmt.Sprintf("🖼️ Evento #%s", frigate.ShortID(eventID)), "", opts); err != nil {
+		log.Printf("Erro ao enviar snapshot do evento %s: %v", eventID, err)
+	}
+}
+
+// sendEventClip baixa o clipe de um evento e envia como resposta à mensagem informada
+func (b *TelegramBot) sendEventClip(ctx context.Context, eventID string, chatID int64, threadID int, replyTo int) {
+	clipCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	clip, err := b.fetchEventClip(clipCtx, eventID)
+	if err != nil {
+		log.Printf("Erro ao obter clipe do evento %s: %v", eventID, err)
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao obter clipe do evento: %v", err), chatID, &threadID))
+		return
+	}
+
+	opts := SendOptions{ChatID: chatID, ThreadID: int64(threadID), ReplyTo: replyTo}
+	if err := b.SendVideo(clipCtx, clip, fmt.Sprintf("🎬 Evento #%s", frigate.ShortID(eventID)), "", opts); err != nil {
+		log.Printf("Erro ao enviar clipe do evento %s: %v", eventID, err)
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao enviar clipe do evento: %v", err), chatID, &threadID))
+	}
+}
+
+// fetchEventClip baixa o clipe de um evento, com retry (o Frigate pode demorar para gerar o clipe)
+func (b *TelegramBot) fetchEventClip(ctx context.Context, eventID string) ([]byte, error) {
+	var lastErr error
+	for attempt := 1; attempt <= eventClipRetries; attempt++ {
+		if attempt > 1 {
+			log.Printf("Tentativa %d de %d de baixar o clipe do evento %s", attempt, eventClipRetries, eventID)
+			time.Sleep(2 * time.Second)
+		}
+
+		clip, err := b.Frigate.GetEventClip(ctx, eventID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(clip) > 0 {
+			return clip, nil
+		}
+		lastErr = fmt.Errorf("clipe vazio recebido")
+	}
+	return nil, fmt.Errorf("falha após %d tentativas: %w", eventClipRetries, lastErr)
+}
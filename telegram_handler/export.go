@@ -0,0 +1,226 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+)
+
+const (
+	// maxExportDuration é a duração máxima de uma exportação pelo Telegram
+	maxExportDuration = time.Hour
+	// exportPollInterval é o intervalo de consulta da exportação em andamento
+	exportPollInterval = 5 * time.Second
+	// exportTimeout é o tempo máximo para o Frigate concluir a exportação
+	exportTimeout = 10 * time.Minute
+)
+
+// exportTimeLayouts são os formatos aceitos para o início da exportação (horário local)
+var exportTimeLayouts = []string{
+	"15:04",
+	"15:04:05",
+	"02/01 15:04",
+	"02/01/2006 15:04",
+	"2006-01-02 15:04",
+}
+
+// parseExportStart converte o início informado no horário local em um horário UTC.
+// Sem data, usa o dia de hoje (ou de ontem, se o horário ainda não chegou).
+func (b *TelegramBot) parseExportStart(text string) (time.Time, error) {
+	return parseExportTime(text, time.Now(), time.Duration(b.TimezoneAjust)*time.Hour)
+}
+
+// parseExportTime converte o início informado no fuso local (UTC + offset) em um horário UTC,
+// completando a data que faltar a partir de now
+func parseExportTime(text string, now time.Time, offset time.Duration) (time.Time, error) {
+	now = now.UTC().Add(offset)
+
+	for _, layout := range exportTimeLayouts {
+		t, err := time.Parse(layout, text)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case !strings.Contains(layout, "/") && !strings.Contains(layout, "-"):
+			t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+			if t.After(now) {
+				t = t.AddDate(0, 0, -1)
+			}
+		case !strings.Contains(layout, "2006"):
+			t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+			if t.After(now) {
+				t = t.AddDate(-1, 0, 0)
+			}
+		}
+		return t.Add(-offset), nil
+	}
+	return time.Time{}, fmt.Errorf("horário inválido: %s (use HH:MM, DD/MM HH:MM ou DD/MM/AAAA HH:MM)", text)
+}
+
+func (b *TelegramBot) handleExport(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+	usage := "Uso: /export <câmera> <início> <duração> (ex.: /export Portao 14:30 10m ou /export Portao 25/12 08:00 5m)"
+
+	// Aceita "/export <câmera> <início> <duração>", onde o início pode ter data e hora
+	args := commandArgs(update.Message.Text)
+	if len(args) < 3 {
+		bot.SendMessage(ctx, stringToMessage(usage, chatID, &threadID))
+		return
+	}
+
+	cameraName := b.findCamera(ctx, args[0])
+	if cameraName == "" {
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Câmera '%s' não encontrada", args[0]), chatID, &threadID))
+		return
+	}
+
+	duration, ok := parseDurationArg(args[len(args)-1])
+	if !ok {
+		bot.SendMessage(ctx, stringToMessage(usage, chatID, &threadID))
+		return
+	}
+	if duration > maxExportDuration {
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("A duração máxima da exportação é %s", FormatDuration(maxExportDuration)), chatID, &threadID))
+		return
+	}
+
+	start, err := b.parseExportStart(strings.Join(args[1:len(args)-1], " "))
+	if err != nil {
+		bot.SendMessage(ctx, stringToMessage(err.Error(), chatID, &threadID))
+		return
+	}
+	end := start.Add(duration)
+	if end.After(time.Now()) {
+		bot.SendMessage(ctx, stringToMessage("O período da exportação ainda não terminou", chatID, &threadID))
+		return
+	}
+
+	go b.runExport(ctx, cameraName, start, end, chatID, threadID, update.Message.ID)
+}
+
+// runExport pede a exportação ao Frigate, acompanha o andamento editando uma mensagem de status
+// e envia o vídeo como resposta ao comando
+func (b *TelegramBot) runExport(ctx context.Context, cameraName string, start, end time.Time, chatID int64, threadID int, replyTo int) {
+	period := fmt.Sprintf("%s %s - %s", cameraName,
+		b.localTime(float64(start.Unix())).Format("02/01 15:04:05"),
+		b.localTime(float64(end.Unix())).Format("15:04:05"))
+
+	statusMessage := stringToMessage(fmt.Sprintf("⏳ Solicitando exportação: %s", period), chatID, &threadID)
+	statusMessage.ReplyParameters = &models.ReplyParameters{MessageID: replyTo, AllowSendingWithoutReply: true}
+	status, err := b.Bot.SendMessage(ctx, statusMessage)
+	if err != nil {
+		log.Printf("Erro ao enviar status da exportação: %v", err)
+		return
+	}
+	setStatus := func(text string) {
+		if _, err := b.Bot.EditMessageText(ctx, &tgbotapi.EditMessageTextParams{
+			ChatID:    status.Chat.ID,
+			MessageID: status.ID,
+			Text:      text,
+		}); err != nil {
+			log.Printf("Erro ao atualizar status da exportação: %v", err)
+		}
+	}
+
+	exportCtx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	name := fmt.Sprintf("telegram-%s-%d", cameraName, start.Unix())
+	exportID, err := b.Frigate.StartExport(exportCtx, cameraName, start.Unix(), end.Unix(), name)
+	if err != nil {
+		log.Printf("Erro ao iniciar exportação da câmera %s: %v", cameraName, err)
+		setStatus(fmt.Sprintf("❌ Erro ao iniciar exportação: %v", err))
+		return
+	}
+
+	// A exportação só é usada para o envio: ela é removida do Frigate ao final, com ou sem sucesso,
+	// para não ocupar o disco de gravações
+	defer func() {
+		deleteCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if exportID == "" {
+			// Frigate antigo, que não informa o ID: procura a exportação pelo nome
+			export, err := b.Frigate.FindExport(deleteCtx, name)
+			if err != nil || export == nil {
+				log.Printf("Exportação %s não encontrada para remoção: %v", name, err)
+				return
+			}
+			exportID = export.ID
+		}
+		if err := b.Frigate.DeleteExport(deleteCtx, exportID); err != nil {
+			log.Printf("Erro ao remover exportação %s do Frigate: %v", exportID, err)
+		}
+	}()
+
+	export, err := b.waitExport(exportCtx, exportID, name, func(elapsed time.Duration) {
+		setStatus(fmt.Sprintf("⏳ Exportando: %s (%s)", period, FormatDuration(elapsed)))
+	})
+	if err != nil {
+		log.Printf("Erro ao aguardar exportação %s: %v", name, err)
+		setStatus(fmt.Sprintf("❌ Erro na exportação: %v", err))
+		return
+	}
+	exportID = export.ID
+
+	setStatus(fmt.Sprintf("⬇️ Baixando exportação: %s", period))
+	video, truncated, err := b.Frigate.DownloadExport(exportCtx, *export, maxVideoSize)
+	if err != nil {
+		log.Printf("Erro ao baixar exportação %s: %v", export.ID, err)
+		setStatus(fmt.Sprintf("❌ Erro ao baixar exportação: %v", err))
+		return
+	}
+
+	setStatus(fmt.Sprintf("📤 Enviando exportação: %s", period))
+	opts := SendOptions{ChatID: chatID, ThreadID: int64(threadID), ReplyTo: replyTo}
+	if err := b.SendVideo(exportCtx, video, fmt.Sprintf("🎞️ Exportação: %s", period), cameraName, opts); err != nil {
+		log.Printf("Erro ao enviar exportação %s: %v", export.ID, err)
+		setStatus(fmt.Sprintf("❌ Erro ao enviar exportação: %v", err))
+		return
+	}
+
+	text := fmt.Sprintf("✅ Exportação enviada: %s", period)
+	if truncated {
+		text += " (vídeo cortado no limite de tamanho do Telegram)"
+	}
+	setStatus(text)
+	log.Printf("Exportação %s enviada para o Telegram.", export.ID)
+}
+
+// waitExport consulta a exportação até ela ser concluída. Sem ID (Frigate antigo), procura a exportação pelo nome.
+func (b *TelegramBot) waitExport(ctx context.Context, exportID, name string, progress func(elapsed time.Duration)) (*frigate.Export, error) {
+	started := time.Now()
+	ticker := time.NewTicker(exportPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tempo esgotado aguardando a exportação: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		var export *frigate.Export
+		var err error
+		if exportID != "" {
+			export, err = b.Frigate.GetExport(ctx, exportID)
+		} else {
+			export, err = b.Frigate.FindExport(ctx, name)
+		}
+		if err != nil {
+			log.Printf("Erro ao consultar exportação %s: %v", name, err)
+			continue
+		}
+		if export != nil && !export.InProgress {
+			return export, nil
+		}
+		progress(time.Since(started))
+	}
+}
@@ -0,0 +1,68 @@
+package telegram_handler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExportTime(t *testing.T) {
+	// 15/03/2026 10:00 no horário local (UTC-3)
+	offset := -3 * time.Hour
+	now := time.Date(2026, 3, 15, 13, 0, 0, 0, time.UTC)
+	local := func(year int, month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, time.UTC).Add(-offset)
+	}
+
+	tests := []struct {
+		text    string
+		want    time.Time
+		wantErr bool
+	}{
+		{"09:30", local(2026, 3, 15, 9, 30, 0), false},
+		{"09:30:15", local(2026, 3, 15, 9, 30, 15), false},
+		{"10:00", local(2026, 3, 15, 10, 0, 0), false},
+		{"11:00", local(2026, 3, 14, 11, 0, 0), false}, // ainda não chegou: ontem
+		{"14/03 23:50", local(2026, 3, 14, 23, 50, 0), false},
+		{"20/12 08:00", local(2025, 12, 20, 8, 0, 0), false}, // ainda não chegou: ano passado
+		{"20/12/2024 08:00", local(2024, 12, 20, 8, 0, 0), false},
+		{"2024-12-20 08:00", local(2024, 12, 20, 8, 0, 0), false},
+		{"25:00", time.Time{}, true},
+		{"ontem", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parseExportTime(tt.text, now, offset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExportTime(%q) erro = %v, esperado erro = %v", tt.text, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseExportTime(%q) = %s, esperado %s", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExportTimeRollover(t *testing.T) {
+	// Logo após a meia-noite de 1º de janeiro (horário local), o horário sem data é de ontem
+	// e a data sem ano é do ano anterior
+	offset := 2 * time.Hour
+	now := time.Date(2025, 12, 31, 22, 10, 0, 0, time.UTC) // 01/01/2026 00:10 local
+
+	got, err := parseExportTime("23:00", now, offset)
+	if err != nil {
+		t.Fatalf("parseExportTime erro = %v", err)
+	}
+	if want := time.Date(2025, 12, 31, 21, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("parseExportTime(23:00) = %s, esperado %s", got, want)
+	}
+
+	got, err = parseExportTime("31/12 23:00", now, offset)
+	if err != nil {
+		t.Fatalf("parseExportTime erro = %v", err)
+	}
+	if want := time.Date(2025, 12, 31, 21, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("parseExportTime(31/12 23:00) = %s, esperado %s", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+)
+
+// shortIDSearchLimit é a quantidade de eventos recentes consultados no Frigate quando o ID curto não está no Redis
+const shortIDSearchLimit = 500
+
+func (b *TelegramBot) handleClip(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	eventID, ok := b.eventIDFromCommand(ctx, update.Message, "/clip")
+	if !ok {
+		return
+	}
+	b.sendEventClip(ctx, eventID, update.Message.Chat.ID, update.Message.MessageThreadID, update.Message.ID)
+}
+
+func (b *TelegramBot) handlePhoto(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	eventID, ok := b.eventIDFromCommand(ctx, update.Message, "/photo")
+	if !ok {
+		return
+	}
+	b.sendEventSnapshot(ctx, eventID, update.Message.Chat.ID, update.Message.MessageThreadID, update.Message.ID)
+}
+
+// eventIDFromCommand resolve o ID curto informado no comando (ex.: "/clip #123456abc123").
+// Retorna false quando o ID não foi informado ou não foi encontrado (nesse caso o usuário já foi avisado).
+func (b *TelegramBot) eventIDFromCommand(ctx context.Context, message *models.Message, command string) (string, bool) {
+	chatID, threadID := message.Chat.ID, message.MessageThreadID
+
+	args := commandArgs(message.Text)
+	if len(args) != 1 {
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Uso: %s <id>", command), chatID, &threadID))
+		return "", false
+	}
+	shortID := strings.TrimPrefix(args[0], "#")
+
+	eventID, err := b.resolveEventID(ctx, shortID)
+	if err != nil {
+		log.Printf("Erro ao resolver ID curto %s: %v", shortID, err)
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao buscar evento: %v", err), chatID, &threadID))
+		return "", false
+	}
+	if eventID == "" {
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Evento #%s não encontrado", shortID), chatID, &threadID))
+		return "", false
+	}
+	return eventID, true
+}
+
+// resolveEventID converte o ID curto das legendas no ID completo do evento: primeiro pelo índice
+// guardado no Redis e, se não estiver lá, procurando entre os eventos recentes do Frigate.
+// Retorna "" quando o evento não é encontrado.
+func (b *TelegramBot) resolveEventID(ctx context.Context, shortID string) (string, error) {
+	eventID, err := b.Redis.GetEventIDByShortID(ctx, shortID)
+	if err != nil {
+		log.Printf("Erro ao buscar ID curto no Redis: %v", err)
+	}
+	if eventID != "" {
+		return eventID, nil
+	}
+
+	events, err := b.Frigate.GetEvents(ctx, frigate.EventsQuery{Limit: shortIDSearchLimit})
+	if err != nil {
+		return "", err
+	}
+	for _, event := range events {
+		if strings.EqualFold(frigate.ShortID(event.ID), shortID) {
+			return event.ID, nil
+		}
+	}
+	return "", nil
+}
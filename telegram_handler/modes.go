@@ -0,0 +1,119 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/config"
+)
+
+// DisarmMode é o nome usado para desarmar (nenhum modo ativo)
+const DisarmMode = "disarm"
+
+// SetMode ativa um modo da configuração (ou desarma, com "" ou DisarmMode) e anuncia a troca no General.
+// source identifica quem fez a troca (ex.: o usuário do Telegram ou "MQTT").
+// Retorna false quando o modo pedido já estava ativo.
+func (b *TelegramBot) SetMode(ctx context.Context, mode string, source string) (bool, error) {
+	mode = strings.TrimSpace(mode)
+	if strings.EqualFold(mode, DisarmMode) {
+		mode = ""
+	}
+	if mode != "" {
+		profile := config.FindMode(b.Modes, mode)
+		if profile == nil {
+			return false, fmt.Errorf("modo '%s' não existe (disponíveis: %s)", mode, strings.Join(b.modeNames(), ", "))
+		}
+		mode = profile.Name
+	}
+
+	current, err := b.Redis.GetActiveMode(ctx)
+	if err != nil {
+		return false, err
+	}
+	if current == mode {
+		return false, nil
+	}
+
+	if err := b.Redis.SetActiveMode(ctx, mode); err != nil {
+		return false, err
+	}
+	log.Printf("Modo alterado de '%s' para '%s' por %s", current, mode, source)
+
+	if err := b.SendMessage(ctx, fmt.Sprintf("%s (por %s)", modeDescription(mode), source), "General"); err != nil {
+		log.Printf("Erro ao anunciar troca de modo: %v", err)
+	}
+	return true, nil
+}
+
+// modeNames retorna os nomes dos modos configurados
+func (b *TelegramBot) modeNames() []string {
+	names := make([]string, 0, len(b.Modes))
+	for _, mode := range b.Modes {
+		names = append(names, mode.Name)
+	}
+	return names
+}
+
+// modeDescription descreve o modo ativo
+func modeDescription(mode string) string {
+	if mode == "" {
+		return "🔓 Desarmado"
+	}
+	return fmt.Sprintf("🛡️ Modo %s ativado", mode)
+}
+
+func (b *TelegramBot) handleArm(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+
+	if len(b.Modes) == 0 {
+		bot.SendMessage(ctx, stringToMessage("Nenhum modo configurado (seção 'modes' do config.yaml)", chatID, &threadID))
+		return
+	}
+
+	args := commandArgs(update.Message.Text)
+	if len(args) != 1 {
+		current, err := b.Redis.GetActiveMode(ctx)
+		if err != nil {
+			log.Printf("Erro ao buscar modo ativo: %v", err)
+		}
+		text := fmt.Sprintf("Uso: /arm <modo>\nModos disponíveis: %s\nAtual: %s", strings.Join(b.modeNames(), ", "), modeDescription(current))
+		bot.SendMessage(ctx, stringToMessage(text, chatID, &threadID))
+		return
+	}
+
+	b.changeMode(ctx, update.Message, args[0])
+}
+
+func (b *TelegramBot) handleDisarm(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	b.changeMode(ctx, update.Message, DisarmMode)
+}
+
+// changeMode troca o modo a pedido de uma mensagem, respondendo apenas quando não houve anúncio no General
+func (b *TelegramBot) changeMode(ctx context.Context, message *models.Message, mode string) {
+	chatID, threadID := message.Chat.ID, message.MessageThreadID
+
+	source := "Telegram"
+	if message.From != nil {
+		source = userName(*message.From)
+	}
+
+	changed, err := b.SetMode(ctx, mode, source)
+	if err != nil {
+		log.Printf("Erro ao trocar modo: %v", err)
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao trocar modo: %v", err), chatID, &threadID))
+		return
+	}
+	if !changed {
+		current, _ := b.Redis.GetActiveMode(ctx)
+		text := "🔓 O sistema já está desarmado"
+		if current != "" {
+			text = fmt.Sprintf("🛡️ O modo %s já está ativo", current)
+		}
+		b.Bot.SendMessage(ctx, stringToMessage(text, chatID, &threadID))
+	}
+}
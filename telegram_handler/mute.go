@@ -0,0 +1,154 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/redis_handler"
+)
+
+// defaultMuteDuration é o tempo de silêncio quando o comando /mute não informa a duração
+const defaultMuteDuration = time.Hour
+
+// parseDurationArg converte a duração informada em um comando: "30m", "2h", "1h30m" ou minutos ("45")
+func parseDurationArg(text string) (time.Duration, bool) {
+	if d, err := time.ParseDuration(text); err == nil {
+		return d, d > 0
+	}
+	if minutes, err := strconv.Atoi(text); err == nil {
+		return time.Duration(minutes) * time.Minute, minutes > 0
+	}
+	return 0, false
+}
+
+// muteTarget define a câmera do /mute e /unmute: "all", a câmera informada ou a câmera da thread.
+// Retorna false quando a câmera não pode ser definida (nesse caso o usuário já foi avisado).
+func (b *TelegramBot) muteTarget(ctx context.Context, message *models.Message, args []string, usage string) (string, bool) {
+	if len(args) > 0 && strings.EqualFold(args[0], "all") {
+		return redis_handler.AllCameras, true
+	}
+
+	cameraName, ok := b.resolveCamera(ctx, message, args)
+	if !ok {
+		return "", false
+	}
+	if cameraName == "" || cameraName == "General" {
+		b.Bot.SendMessage(ctx, stringToMessage(usage, message.Chat.ID, &message.MessageThreadID))
+		return "", false
+	}
+	return cameraName, true
+}
+
+// muteName retorna o nome exibido da câmera silenciada
+func muteName(camera string) string {
+	if camera == redis_handler.AllCameras {
+		return "Todas as câmeras"
+	}
+	return fmt.Sprintf("Câmera %s", camera)
+}
+
+func (b *TelegramBot) handleMute(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+	usage := "Uso: /mute [câmera|all] [duração] (ex.: /mute Portao 2h). Na thread da câmera, a câmera pode ser omitida."
+
+	// Aceita "/mute [câmera|all] [duração]"
+	args := commandArgs(update.Message.Text)
+	duration := defaultMuteDuration
+	if len(args) > 0 {
+		if d, ok := parseDurationArg(args[len(args)-1]); ok {
+			duration = d
+			args = args[:len(args)-1]
+		}
+	}
+	if len(args) > 1 {
+		bot.SendMessage(ctx, stringToMessage(usage, chatID, &threadID))
+		return
+	}
+
+	cameraName, ok := b.muteTarget(ctx, update.Message, args, usage)
+	if !ok {
+		return
+	}
+
+	if err := b.Redis.MuteCamera(ctx, cameraName, duration); err != nil {
+		log.Printf("Erro ao silenciar câmera %s: %v", cameraName, err)
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao silenciar câmera: %v", err), chatID, &threadID))
+		return
+	}
+
+	until := b.localTime(float64(time.Now().Add(duration).Unix()))
+	bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("🔕 %s silenciada por %s (até %s)", muteName(cameraName), FormatDuration(duration), until.Format("02/01 15:04")), chatID, &threadID))
+}
+
+func (b *TelegramBot) handleUnmute(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+	usage := "Uso: /unmute [câmera|all]. Na thread da câmera, a câmera pode ser omitida."
+
+	args := commandArgs(update.Message.Text)
+	if len(args) > 1 {
+		bot.SendMessage(ctx, stringToMessage(usage, chatID, &threadID))
+		return
+	}
+
+	cameraName, ok := b.muteTarget(ctx, update.Message, args, usage)
+	if !ok {
+		return
+	}
+
+	unmuted, err := b.Redis.UnmuteCamera(ctx, cameraName)
+	if err != nil {
+		log.Printf("Erro ao reativar câmera %s: %v", cameraName, err)
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao reativar câmera: %v", err), chatID, &threadID))
+		return
+	}
+
+	text := fmt.Sprintf("🔔 %s reativada", muteName(cameraName))
+	if cameraName == redis_handler.AllCameras {
+		text = "🔔 Todos os silêncios foram encerrados"
+	}
+	if !unmuted {
+		text = fmt.Sprintf("%s não estava silenciada", muteName(cameraName))
+		if cameraName == redis_handler.AllCameras {
+			text = "Nenhuma câmera estava silenciada"
+		}
+	}
+	bot.SendMessage(ctx, stringToMessage(text, chatID, &threadID))
+}
+
+func (b *TelegramBot) handleMutes(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+
+	mutes, err := b.Redis.ListMutes(ctx)
+	if err != nil {
+		log.Printf("Erro ao listar silêncios: %v", err)
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao listar silêncios: %v", err), chatID, &threadID))
+		return
+	}
+	if len(mutes) == 0 {
+		bot.SendMessage(ctx, stringToMessage("🔔 Nenhuma câmera silenciada", chatID, &threadID))
+		return
+	}
+
+	// O silêncio de todas as câmeras aparece primeiro
+	sort.Slice(mutes, func(i, j int) bool {
+		if mutes[i].Camera == redis_handler.AllCameras || mutes[j].Camera == redis_handler.AllCameras {
+			return mutes[i].Camera == redis_handler.AllCameras
+		}
+		return mutes[i].Camera < mutes[j].Camera
+	})
+
+	lines := []string{"🔕 Silêncios ativos:"}
+	for _, mute := range mutes {
+		until := b.localTime(float64(time.Now().Add(mute.Remaining).Unix()))
+		lines = append(lines, fmt.Sprintf("• %s - restam %s (até %s)", muteName(mute.Camera), FormatDuration(mute.Remaining), until.Format("02/01 15:04")))
+	}
+	bot.SendMessage(ctx, stringToMessage(strings.Join(lines, "\n"), chatID, &threadID))
+}
@@ -0,0 +1,67 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+)
+
+const (
+	// recordingPollInterval é o intervalo de consulta do evento manual até o clipe ficar pronto
+	recordingPollInterval = 5 * time.Second
+	// recordingGracePeriod é o tempo extra, além da duração da gravação, para o Frigate gerar o clipe
+	recordingGracePeriod = 3 * time.Minute
+	// maxRecordDuration é a duração máxima, em segundos, de uma gravação pelo /record
+	maxRecordDuration = 300
+)
+
+// deliverRecording espera o evento manual terminar e o clipe ficar disponível, e envia o clipe
+// como resposta à mensagem do /record
+func (b *TelegramBot) deliverRecording(ctx context.Context, eventID string, duration int, chatID int64, threadID int, replyTo int) {
+	waitCtx, cancel := context.WithTimeout(ctx, time.Duration(duration)*time.Second+recordingGracePeriod)
+	defer cancel()
+
+	ticker := time.NewTicker(recordingPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			log.Printf("Timeout aguardando o clipe do evento manual %s: %v", eventID, waitCtx.Err())
+			text := fmt.Sprintf("⚠️ O clipe da gravação não ficou pronto a tempo. Tente mais tarde com /clip %s", frigate.ShortID(eventID))
+			b.Bot.SendMessage(ctx, stringToMessage(text, chatID, &threadID))
+			return
+		case <-ticker.C:
+		}
+
+		event, err := b.Frigate.GetEvent(waitCtx, eventID)
+		if err != nil {
+			log.Printf("Erro ao consultar evento manual %s: %v", eventID, err)
+			continue
+		}
+		// O Frigate pode preencher end_time já na criação, com o horário previsto para o fim
+		if !event.HasClip || event.EndTime == 0 || float64(time.Now().Unix()) < event.EndTime {
+			continue
+		}
+
+		// Os segmentos finais da gravação podem demorar alguns segundos para ficarem disponíveis
+		clip, err := b.Frigate.GetEventClip(waitCtx, eventID)
+		if err != nil || len(clip) == 0 {
+			log.Printf("Clipe do evento manual %s ainda não disponível: %v", eventID, err)
+			continue
+		}
+
+		caption := fmt.Sprintf("🎬 Gravação da câmera %s\n🔗 #%s", event.Camera, frigate.ShortID(eventID))
+		opts := SendOptions{ChatID: chatID, ThreadID: int64(threadID), ReplyTo: replyTo}
+		if err := b.SendVideo(waitCtx, clip, caption, event.Camera, opts); err != nil {
+			log.Printf("Erro ao enviar clipe do evento manual %s: %v", eventID, err)
+			b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao enviar clipe da gravação: %v", err), chatID, &threadID))
+			return
+		}
+		log.Printf("Clipe do evento manual %s enviado para o Telegram.", eventID)
+		return
+	}
+}
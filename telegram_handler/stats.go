@@ -0,0 +1,103 @@
+package telegram_handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/geffersonFerraz/frigate-events-telegram/frigate"
+	tgbotapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// sortedKeys retorna as chaves de um mapa em ordem alfabética
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// cpuUsage retorna o uso de CPU de um processo do Frigate pelo PID
+func cpuUsage(stats *frigate.Stats, pid int) string {
+	if usage, ok := stats.CPUUsages[strconv.Itoa(pid)]; ok && usage.CPU != "" {
+		return usage.CPU + "%"
+	}
+	return "-"
+}
+
+// formatCameraStats formata as estatísticas de uma câmera
+func formatCameraStats(stats *frigate.Stats, name string, camera frigate.CameraStats) string {
+	return fmt.Sprintf("• %s: %.1f fps | detecção %.1f fps | ignorados %.1f fps | CPU %s",
+		name, camera.CameraFPS, camera.DetectionFPS, camera.SkippedFPS, cpuUsage(stats, camera.PID))
+}
+
+// formatStats monta o texto do /stats; com cameraName de uma câmera existente mostra apenas ela
+func formatStats(stats *frigate.Stats, cameraName string) string {
+	if camera, ok := stats.Cameras[cameraName]; ok {
+		return strings.Join([]string{
+			fmt.Sprintf("📊 Estatísticas da câmera %s", cameraName),
+			formatCameraStats(stats, cameraName, camera),
+		}, "\n")
+	}
+
+	lines := []string{
+		fmt.Sprintf("📊 Frigate %s", stats.Service.Version),
+		fmt.Sprintf("🕒 Tempo de atividade: %s", FormatDuration(secondsToDuration(stats.Service.Uptime))),
+		fmt.Sprintf("🔎 Detecção total: %.1f fps", stats.DetectionFPS),
+		"",
+		"🎥 Câmeras:",
+	}
+	for _, name := range sortedKeys(stats.Cameras) {
+		lines = append(lines, formatCameraStats(stats, name, stats.Cameras[name]))
+	}
+
+	if len(stats.Detectors) > 0 {
+		lines = append(lines, "", "🧠 Detectores:")
+		for _, name := range sortedKeys(stats.Detectors) {
+			detector := stats.Detectors[name]
+			lines = append(lines, fmt.Sprintf("• %s: %.2f ms | CPU %s", name, detector.InferenceSpeed, cpuUsage(stats, detector.PID)))
+		}
+	}
+
+	if system, ok := stats.CPUUsages["frigate.full_system"]; ok {
+		lines = append(lines, "", fmt.Sprintf("💻 CPU do sistema: %s%% | memória %s%%", system.CPU, system.Mem))
+	}
+
+	if len(stats.GPUUsages) > 0 {
+		lines = append(lines, "", "🎮 GPUs:")
+		for _, name := range sortedKeys(stats.GPUUsages) {
+			gpu := stats.GPUUsages[name]
+			lines = append(lines, fmt.Sprintf("• %s: %s | memória %s", name, gpu.GPU, gpu.Mem))
+		}
+	}
+
+	if len(stats.Service.Storage) > 0 {
+		lines = append(lines, "", "💾 Armazenamento:")
+		for _, path := range sortedKeys(stats.Service.Storage) {
+			storage := stats.Service.Storage[path]
+			percent := 0.0
+			if storage.Total > 0 {
+				percent = storage.Used / storage.Total * 100
+			}
+			lines = append(lines, fmt.Sprintf("• %s: %.1f / %.1f GB (%.0f%%)", path, storage.Used/1024, storage.Total/1024, percent))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (b *TelegramBot) handleStats(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
+	stats, err := b.Frigate.GetStats(ctx)
+	if err != nil {
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao obter estatísticas do Frigate: %v", err), update.Message.Chat.ID, &update.Message.MessageThreadID))
+		return
+	}
+
+	cameraName := b.getCameraName(int64(update.Message.MessageThreadID))
+	bot.SendMessage(ctx, stringToMessage(formatStats(stats, cameraName), update.Message.Chat.ID, &update.Message.MessageThreadID))
+}
@@ -30,6 +30,46 @@ type TelegramBot struct {
 	StartTime     time.Time
 	Redis         *redis_handler.RedisHandler
 	Frigate       *frigate.Frigate
+	TimezoneAjust int
+	Modes         []config.ModeConfig
+	Auth          config.AuthConfig
+}
+
+const (
+	// MaxAlbumSize é o número máximo de mídias em um álbum do Telegram
+	MaxAlbumSize = 10
+	// maxCaptionLength é o tamanho máximo de uma legenda no Telegram
+	maxCaptionLength = 1024
+	// maxVideoSize é o tamanho máximo de vídeo enviado; acima disso o vídeo é cortado
+	maxVideoSize = 49 * 1024 * 1024
+)
+
+// SentMessage identifica uma mensagem enviada pelo bot
+type SentMessage struct {
+	ChatID    int64
+	MessageID int
+	ThreadID  int
+	EventID   string // preenchido quando a mensagem tem os botões de ação do evento
+}
+
+// SendOptions define opções adicionais para o envio de mídias
+type SendOptions struct {
+	Silent   bool   // envia sem som de notificação (disable_notification)
+	ReplyTo  int    // ID da mensagem respondida (0 = nenhuma)
+	ChatID   int64  // chat de destino (0 = chat padrão)
+	ThreadID int64  // thread de destino (0 = thread da câmera no chat padrão, quando UseThreadIDs estiver ativo)
+	EventID  string // quando informado, a foto é enviada com os botões de ação do evento
+}
+
+// replyParameters monta os parâmetros de resposta a partir das opções de envio
+func (o SendOptions) replyParameters() *models.ReplyParameters {
+	if o.ReplyTo == 0 {
+		return nil
+	}
+	return &models.ReplyParameters{
+		MessageID:                o.ReplyTo,
+		AllowSendingWithoutReply: true,
+	}
 }
 
 type Telegram interface {
@@ -37,17 +77,17 @@ type Telegram interface {
 	RegisterHandlers(ctx context.Context)
 	Stop(ctx context.Context) (bool, error)
 	SendMessage(ctx context.Context, text string, cameraName string) error
-	SendPhoto(ctx context.Context, photoBytes []byte, caption string, cameraName string) error
-	SendVideo(ctx context.Context, videoBytes []byte, caption string, cameraName string) error
+	SendText(ctx context.Context, text string, cameraName string, opts SendOptions) (SentMessage, error)
+	SendPhoto(ctx context.Context, photoBytes []byte, caption string, cameraName string, opts SendOptions) (SentMessage, error)
+	SendAlbum(ctx context.Context, photos [][]byte, caption string, cameraName string, opts SendOptions) ([]SentMessage, error)
+	EditPhoto(ctx context.Context, msg SentMessage, photoBytes []byte, caption string) error
+	EditCaption(ctx context.Context, msg SentMessage, caption string) error
+	SendVideo(ctx context.Context, videoBytes []byte, caption string, cameraName string, opts SendOptions) error
+	SetMode(ctx context.Context, mode string, source string) (bool, error)
 }
 
 // NewBot cria uma nova instância do TelegramBot
 func NewBot(config TelegramBot) (Telegram, error) {
-	bot, err := tgbotapi.New(config.Token)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao criar bot: %w", err)
-	}
-
 	cameraThreadIDs := make(map[string]int64)
 	for _, group := range config.Groups {
 		cameraThreadIDs[group.Name] = group.ID
@@ -59,11 +99,23 @@ func NewBot(config TelegramBot) (Telegram, error) {
 		Groups:        config.Groups,
 		UseThreadIDs:  config.UseThreadIDs,
 		StartTime:     time.Now(),
-		Bot:           bot,
 		Redis:         config.Redis,
 		Frigate:       config.Frigate,
+		TimezoneAjust: config.TimezoneAjust,
+		Modes:         config.Modes,
+		Auth:          config.Auth,
 	}
 
+	if len(tb.Auth.Admins) == 0 {
+		log.Println("Aviso: nenhum admin configurado em 'auth.admins'; os comandos administrativos (/clean, /restart, /record, /arm, /disarm, /mute, /unmute, /export) e os botões de ação ficam desativados")
+	}
+
+	bot, err := tgbotapi.New(config.Token, tgbotapi.WithMiddlewares(tb.authMiddleware))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar bot: %w", err)
+	}
+	tb.Bot = bot
+
 	return tb, nil
 }
 
@@ -78,11 +130,26 @@ func (b *TelegramBot) Stop(ctx context.Context) (bool, error) {
 // RegisterHandler registra um handler para o bot
 func (b *TelegramBot) RegisterHandlers(ctx context.Context) {
 	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/status", tgbotapi.MatchTypePrefix, b.handleStatus)
-	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/clean", tgbotapi.MatchTypePrefix, b.handleClean)
-	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/restart", tgbotapi.MatchTypePrefix, b.handleRestart)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/stats", tgbotapi.MatchTypePrefix, b.handleStats)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/clean", tgbotapi.MatchTypePrefix, b.handleClean, b.adminOnly)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/restart", tgbotapi.MatchTypePrefix, b.handleRestart, b.adminOnly)
 	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/help", tgbotapi.MatchTypePrefix, b.handleHelp)
 	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/snapshot", tgbotapi.MatchTypePrefix, b.handleSnapshot)
-	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/record", tgbotapi.MatchTypePrefix, b.handleRecord)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/cameras", tgbotapi.MatchTypePrefix, b.handleCameras)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/record", tgbotapi.MatchTypePrefix, b.handleRecord, b.adminOnly)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeCallbackQueryData, cameraCallbackPrefix, tgbotapi.MatchTypePrefix, b.handleCameraCallback)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/events", tgbotapi.MatchTypePrefix, b.handleEvents)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeCallbackQueryData, eventsCallbackPrefix, tgbotapi.MatchTypePrefix, b.handleEventsCallback)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/clip", tgbotapi.MatchTypePrefix, b.handleClip)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/photo", tgbotapi.MatchTypePrefix, b.handlePhoto)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeCallbackQueryData, actionCallbackPrefix, tgbotapi.MatchTypePrefix, b.handleActionCallback)
+	// "/mutes" precisa ser registrado antes de "/mute", pois o primeiro handler com o prefixo é o usado
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/mutes", tgbotapi.MatchTypePrefix, b.handleMutes)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/mute", tgbotapi.MatchTypePrefix, b.handleMute, b.adminOnly)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/unmute", tgbotapi.MatchTypePrefix, b.handleUnmute, b.adminOnly)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/arm", tgbotapi.MatchTypePrefix, b.handleArm, b.adminOnly)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/disarm", tgbotapi.MatchTypePrefix, b.handleDisarm, b.adminOnly)
+	b.Bot.RegisterHandler(tgbotapi.HandlerTypeMessageText, "/export", tgbotapi.MatchTypePrefix, b.handleExport, b.adminOnly)
 }
 
 // SendMessage envia uma mensagem de texto para o chat especificado
@@ -101,34 +168,147 @@ func (b *TelegramBot) SendMessage(ctx context.Context, text string, cameraName s
 	return nil
 }
 
-// SendPhoto envia uma foto para o chat especificado
-func (b *TelegramBot) SendPhoto(ctx context.Context, photoBytes []byte, caption string, cameraName string) error {
-	photo := &models.InputMediaPhoto{
-		Media:           "attach://" + uuid.New().String() + ".jpg",
-		MediaAttachment: bytes.NewReader(photoBytes),
-		Caption:         caption,
+// SendText envia uma mensagem de texto respeitando as opções de envio (destino, resposta, silêncio)
+func (b *TelegramBot) SendText(ctx context.Context, text string, cameraName string, opts SendOptions) (SentMessage, error) {
+	chatID, threadID := b.destination(cameraName, opts)
+	m, err := b.Bot.SendMessage(ctx, &tgbotapi.SendMessageParams{
+		ChatID:              chatID,
+		MessageThreadID:     threadID,
+		Text:                text,
+		DisableNotification: opts.Silent,
+		ReplyParameters:     opts.replyParameters(),
+	})
+	if err != nil {
+		return SentMessage{}, fmt.Errorf("erro ao enviar mensagem: %w", err)
 	}
-	medias := []models.InputMedia{
-		photo,
+	return SentMessage{ChatID: m.Chat.ID, MessageID: m.ID, ThreadID: m.MessageThreadID}, nil
+}
+
+// SendPhoto envia uma foto para o chat especificado. Diferente do álbum, a foto pode levar
+// os botões de ação do evento (opts.EventID).
+func (b *TelegramBot) SendPhoto(ctx context.Context, photoBytes []byte, caption string, cameraName string, opts SendOptions) (SentMessage, error) {
+	chatID, threadID := b.destination(cameraName, opts)
+	params := &tgbotapi.SendPhotoParams{
+		ChatID:              chatID,
+		MessageThreadID:     threadID,
+		Photo:               &models.InputFileUpload{Filename: uuid.New().String() + ".jpg", Data: bytes.NewReader(photoBytes)},
+		Caption:             truncateCaption(caption),
+		DisableNotification: opts.Silent,
+		ReplyParameters:     opts.replyParameters(),
+	}
+	if opts.EventID != "" {
+		params.ReplyMarkup = b.eventKeyboard(ctx, opts.EventID)
+	}
+
+	m, err := b.Bot.SendPhoto(ctx, params)
+	if err != nil {
+		return SentMessage{}, fmt.Errorf("erro ao enviar foto: %w", err)
+	}
+
+	sent := SentMessage{ChatID: m.Chat.ID, MessageID: m.ID, ThreadID: m.MessageThreadID}
+	if opts.EventID != "" {
+		sent.EventID = opts.EventID
+	}
+	return sent, nil
+}
+
+// SendAlbum envia até 10 fotos em um único álbum, com a legenda no primeiro item
+func (b *TelegramBot) SendAlbum(ctx context.Context, photos [][]byte, caption string, cameraName string, opts SendOptions) ([]SentMessage, error) {
+	if len(photos) == 0 || len(photos) > MaxAlbumSize {
+		return nil, fmt.Errorf("álbum deve ter entre 1 e %d fotos, recebido %d", MaxAlbumSize, len(photos))
 	}
 
+	medias := make([]models.InputMedia, 0, len(photos))
+	for i, photoBytes := range photos {
+		photo := &models.InputMediaPhoto{
+			Media:           "attach://" + uuid.New().String() + ".jpg",
+			MediaAttachment: bytes.NewReader(photoBytes),
+		}
+		if i == 0 {
+			photo.Caption = truncateCaption(caption)
+		}
+		medias = append(medias, photo)
+	}
+
+	chatID, threadID := b.destination(cameraName, opts)
 	message := &tgbotapi.SendMediaGroupParams{
-		ChatID: b.DefaultChatID,
-		Media:  medias,
+		ChatID:              chatID,
+		MessageThreadID:     threadID,
+		Media:               medias,
+		DisableNotification: opts.Silent,
+		ReplyParameters:     opts.replyParameters(),
 	}
-	if b.UseThreadIDs {
-		message.MessageThreadID = int(b.getChatID(cameraName))
+
+	messages, err := b.Bot.SendMediaGroup(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao enviar foto: %w", err)
+	}
+	if len(messages) != len(photos) {
+		return nil, fmt.Errorf("telegram retornou %d mensagens para %d fotos", len(messages), len(photos))
 	}
 
-	_, err := b.Bot.SendMediaGroup(ctx, message)
+	sent := make([]SentMessage, 0, len(messages))
+	for _, m := range messages {
+		sent = append(sent, SentMessage{ChatID: m.Chat.ID, MessageID: m.ID, ThreadID: m.MessageThreadID})
+	}
+	return sent, nil
+}
+
+// EditPhoto substitui a foto e a legenda de uma mensagem já enviada
+func (b *TelegramBot) EditPhoto(ctx context.Context, msg SentMessage, photoBytes []byte, caption string) error {
+	params := &tgbotapi.EditMessageMediaParams{
+		ChatID:    msg.ChatID,
+		MessageID: msg.MessageID,
+		Media: &models.InputMediaPhoto{
+			Media:           "attach://" + uuid.New().String() + ".jpg",
+			MediaAttachment: bytes.NewReader(photoBytes),
+			Caption:         truncateCaption(caption),
+		},
+	}
+	// A edição remove o teclado inline se ele não for reenviado
+	if msg.EventID != "" {
+		params.ReplyMarkup = b.eventKeyboard(ctx, msg.EventID)
+	}
+	_, err := b.Bot.EditMessageMedia(ctx, params)
 	if err != nil {
-		return fmt.Errorf("erro ao enviar foto: %w", err)
+		return fmt.Errorf("erro ao editar foto: %w", err)
 	}
 	return nil
 }
 
+// EditCaption substitui a legenda de uma mensagem já enviada
+func (b *TelegramBot) EditCaption(ctx context.Context, msg SentMessage, caption string) error {
+	params := &tgbotapi.EditMessageCaptionParams{
+		ChatID:    msg.ChatID,
+		MessageID: msg.MessageID,
+		Caption:   truncateCaption(caption),
+	}
+	if msg.EventID != "" {
+		params.ReplyMarkup = b.eventKeyboard(ctx, msg.EventID)
+	}
+	_, err := b.Bot.EditMessageCaption(ctx, params)
+	if err != nil {
+		return fmt.Errorf("erro ao editar legenda: %w", err)
+	}
+	return nil
+}
+
+// truncateCaption corta a legenda no limite de caracteres do Telegram
+func truncateCaption(caption string) string {
+	runes := []rune(caption)
+	if len(runes) <= maxCaptionLength {
+		return caption
+	}
+	return string(runes[:maxCaptionLength-1]) + "…"
+}
+
 // SendVideo envia um vídeo para o chat especificado
-func (b *TelegramBot) SendVideo(ctx context.Context, videoBytes []byte, caption string, cameraName string) error {
+func (b *TelegramBot) SendVideo(ctx context.Context, videoBytes []byte, caption string, cameraName string, opts SendOptions) error {
+	// if videoBytes > 49mb, split using first 49mb and send it
+	if len(videoBytes) > maxVideoSize {
+		videoBytes = videoBytes[:maxVideoSize]
+	}
+
 	video := &models.InputMediaVideo{
 		Media:           "attach://" + uuid.New().String() + ".mp4",
 		MediaAttachment: bytes.NewReader(videoBytes),
@@ -139,12 +319,13 @@ func (b *TelegramBot) SendVideo(ctx context.Context, videoBytes []byte, caption
 		video,
 	}
 
+	chatID, threadID := b.destination(cameraName, opts)
 	message := &tgbotapi.SendMediaGroupParams{
-		ChatID: b.DefaultChatID,
-		Media:  medias,
-	}
-	if b.UseThreadIDs {
-		message.MessageThreadID = int(b.getChatID(cameraName))
+		ChatID:              chatID,
+		MessageThreadID:     threadID,
+		Media:               medias,
+		DisableNotification: opts.Silent,
+		ReplyParameters:     opts.replyParameters(),
 	}
 
 	_, err := b.Bot.SendMediaGroup(ctx, message)
@@ -167,6 +348,23 @@ func (b *TelegramBot) getChatID(cameraName string) int64 {
 	return b.DefaultChatID
 }
 
+// destination retorna o chat e a thread de destino de uma mídia, considerando as opções de envio
+func (b *TelegramBot) destination(cameraName string, opts SendOptions) (int64, int) {
+	chatID := b.DefaultChatID
+	if opts.ChatID != 0 {
+		chatID = opts.ChatID
+	}
+
+	// Com o chat informado explicitamente, a thread também é a informada (sem redirecionar para a da câmera)
+	threadID := 0
+	if opts.ThreadID != 0 {
+		threadID = int(opts.ThreadID)
+	} else if b.UseThreadIDs && opts.ChatID == 0 {
+		threadID = int(b.getChatID(cameraName))
+	}
+	return chatID, threadID
+}
+
 func (b *TelegramBot) getCameraName(chatID int64) string {
 	for _, group := range b.Groups {
 		if group.ID == chatID {
@@ -189,7 +387,7 @@ func (b *TelegramBot) handleStatus(ctx context.Context, bot *tgbotapi.Bot, updat
 
 	// Formatar tempo de atividade
 	uptime := time.Since(b.StartTime)
-	uptimeStr := formatDuration(uptime)
+	uptimeStr := FormatDuration(uptime)
 
 	statusInfo := []string{
 		"✅ Sistema em execução",
@@ -198,6 +396,17 @@ func (b *TelegramBot) handleStatus(ctx context.Context, bot *tgbotapi.Bot, updat
 		fmt.Sprintf("💻 Núcleos de CPU disponíveis: %d", cpuUsage),
 	}
 
+	if len(b.Modes) > 0 {
+		mode, err := b.Redis.GetActiveMode(ctx)
+		if err != nil {
+			log.Printf("Erro ao buscar modo ativo: %v", err)
+		} else if mode == "" {
+			statusInfo = append(statusInfo, "🔓 Modo: desarmado")
+		} else {
+			statusInfo = append(statusInfo, fmt.Sprintf("🛡️ Modo: %s", mode))
+		}
+	}
+
 	cameraName := b.getCameraName(update.Message.Chat.ID)
 
 	if cameraName != "" {
@@ -214,8 +423,18 @@ func (b *TelegramBot) handleStatus(ctx context.Context, bot *tgbotapi.Bot, updat
 	bot.SendMessage(ctx, message)
 }
 
-// formatDuration formata uma duração em um formato mais legível
-func formatDuration(d time.Duration) string {
+// secondsToDuration converte segundos em time.Duration
+func secondsToDuration(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
+
+// localTime converte um timestamp do Frigate para o horário local, aplicando o ajuste de fuso configurado
+func (b *TelegramBot) localTime(timestamp float64) time.Time {
+	return time.Unix(int64(timestamp), 0).Add(time.Duration(b.TimezoneAjust) * time.Hour)
+}
+
+// FormatDuration formata uma duração em um formato mais legível
+func FormatDuration(d time.Duration) string {
 	days := int(d.Hours() / 24)
 	hours := int(d.Hours()) % 24
 	minutes := int(d.Minutes()) % 60
@@ -244,12 +463,23 @@ func (b *TelegramBot) handleRestart(ctx context.Context, bot *tgbotapi.Bot, upda
 
 func (b *TelegramBot) handleHelp(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
 	commands := []string{
-		"🔄 /restart - Reinicia o bot",
-		"📸 /snapshot - Tira um snapshot da câmera da thread atual",
-		"🧹 /clean - Limpa dados temporários",
+		"🔄 /restart - Reinicia o bot (admin)",
+		"📸 /snapshot [câmera] - Tira um snapshot da câmera informada ou da thread atual",
+		"🏠 /snapshot all ou /cameras - Envia o snapshot de todas as câmeras",
+		"🧹 /clean - Limpa dados temporários (admin)",
 		"ℹ️ /status - Mostra o status do sistema",
+		"📊 /stats - Mostra as estatísticas do Frigate (na thread de uma câmera, apenas dela)",
 		"❓ /help - Mostra esta mensagem de ajuda",
-		"🎥 /record [segundos]- Cria um evento de gravação da câmera da thread atual",
+		"🎥 /record [câmera] [segundos] - Grava a câmera informada ou da thread atual e envia o clipe ao terminar (admin)",
+		"📋 /events [câmera] [label] [horas] - Lista os eventos recentes do Frigate",
+		"🎬 /clip <id> - Envia o clipe do evento (ID da legenda, ex.: #123456abc123)",
+		"🖼️ /photo <id> - Envia o snapshot do evento (ID da legenda)",
+		"🔕 /mute [câmera|all] [duração] - Silencia as notificações (padrão: 1h) (admin)",
+		"🔔 /unmute [câmera|all] - Encerra o silêncio antes do tempo (admin)",
+		"📋 /mutes - Lista os silêncios ativos e o tempo restante",
+		"🛡️ /arm <modo> - Ativa um modo de notificação (ex.: away, night) (admin)",
+		"🔓 /disarm - Desativa o modo ativo (admin)",
+		"🎞️ /export <câmera> <início> <duração> - Exporta a gravação de um período (ex.: /export Portao 14:30 10m) (admin)",
 	}
 
 	bot.SendMessage(ctx, stringToMessage(strings.Join(commands, "\n"), update.Message.Chat.ID, &update.Message.MessageThreadID))
@@ -267,42 +497,88 @@ func stringToMessage(text string, chatID int64, messageThreadID *int) *tgbotapi.
 }
 
 func (b *TelegramBot) handleSnapshot(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
-	cameraName := b.getCameraName(int64(update.Message.MessageThreadID))
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
+
+	args := commandArgs(update.Message.Text)
+	if len(args) > 0 && strings.EqualFold(args[0], "all") {
+		b.sendAllSnapshots(ctx, chatID, threadID)
+		return
+	}
+
+	cameraName, ok := b.resolveCamera(ctx, update.Message, args)
+	if !ok {
+		return
+	}
 	if cameraName == "" {
-		bot.SendMessage(ctx, stringToMessage("Nenhuma câmera selecionada", update.Message.Chat.ID, &update.Message.MessageThreadID))
+		b.sendCameraPicker(ctx, chatID, threadID, update.Message.ID, "snapshot")
 		return
 	}
 
+	b.sendSnapshot(ctx, cameraName, chatID, threadID)
+}
+
+// sendSnapshot envia o snapshot atual da câmera para o chat que o solicitou
+func (b *TelegramBot) sendSnapshot(ctx context.Context, cameraName string, chatID int64, threadID int) {
 	snapshot, err := b.Frigate.GetSnapshot(ctx, cameraName)
 	if err != nil {
-		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao obter snapshot: %v", err), update.Message.Chat.ID, &update.Message.MessageThreadID))
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao obter snapshot: %v", err), chatID, &threadID))
 		return
 	}
 
-	b.SendPhoto(ctx, snapshot, fmt.Sprintf("Snapshot da câmera %s", cameraName), cameraName)
+	b.SendPhoto(ctx, snapshot, fmt.Sprintf("Snapshot da câmera %s", cameraName), cameraName, SendOptions{ChatID: chatID, ThreadID: int64(threadID)})
 }
 
 func (b *TelegramBot) handleRecord(ctx context.Context, bot *tgbotapi.Bot, update *models.Update) {
-	cameraName := b.getCameraName(int64(update.Message.MessageThreadID))
-	if cameraName == "" {
-		bot.SendMessage(ctx, stringToMessage("Nenhuma câmera selecionada", update.Message.Chat.ID, &update.Message.MessageThreadID))
-		return
-	}
+	chatID, threadID := update.Message.Chat.ID, update.Message.MessageThreadID
 
+	// Aceita "/record [segundos]" e "/record <câmera> [segundos]"
+	args := commandArgs(update.Message.Text)
 	duration := 10
-	var err error
-	if update.Message.Text != "/record" {
-		duration, err = strconv.Atoi(strings.Split(update.Message.Text, " ")[1])
-		if err != nil {
-			bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao converter tempo: %v", err), update.Message.Chat.ID, &update.Message.MessageThreadID))
+	if len(args) > 0 {
+		if seconds, err := strconv.Atoi(args[len(args)-1]); err == nil {
+			duration = seconds
+			args = args[:len(args)-1]
+		} else if len(args) > 1 {
+			bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao converter tempo: %v", err), chatID, &threadID))
 			return
 		}
 	}
+	if duration < 1 || duration > maxRecordDuration {
+		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("A duração da gravação deve ser entre 1 e %d segundos", maxRecordDuration), chatID, &threadID))
+		return
+	}
+
+	cameraName, ok := b.resolveCamera(ctx, update.Message, args)
+	if !ok {
+		return
+	}
+	if cameraName == "" {
+		b.sendCameraPicker(ctx, chatID, threadID, update.Message.ID, fmt.Sprintf("record:%d", duration))
+		return
+	}
+
+	b.createRecording(ctx, cameraName, duration, chatID, threadID, update.Message.ID)
+}
 
-	_, err = b.Frigate.CreateEvent(ctx, cameraName, duration)
+// createRecording cria um evento manual de gravação na câmera e, em segundo plano, envia o clipe
+// como resposta à mensagem replyTo quando a gravação terminar
+func (b *TelegramBot) createRecording(ctx context.Context, cameraName string, duration int, chatID int64, threadID int, replyTo int) {
+	if duration < 1 || duration > maxRecordDuration {
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("A duração da gravação deve ser entre 1 e %d segundos", maxRecordDuration), chatID, &threadID))
+		return
+	}
+
+	eventID, err := b.Frigate.CreateEvent(ctx, cameraName, duration)
 	if err != nil {
-		bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao criar evento: %v", err), update.Message.Chat.ID, &update.Message.MessageThreadID))
+		b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Erro ao criar evento: %v", err), chatID, &threadID))
 		return
 	}
-	bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Evento criado com sucesso, aguarde a gravação ser processada"), update.Message.Chat.ID, &update.Message.MessageThreadID))
+
+	// Os eventos criados pelo bot não geram a notificação normal: o clipe é entregue aqui
+	if err := b.Redis.MarkEventAsProcessed(ctx, eventID, frigate.ManualEventLabel); err != nil {
+		log.Printf("Erro ao marcar evento manual %s no Redis: %v", eventID, err)
+	}
+
+	b.Bot.SendMessage(ctx, stringToMessage(fmt.Sprintf("Evento criado com sucesso na câmera %s, o clipe será enviado quando a gravação terminar", cameraName), chatID, &threadID))
+	go b.deliverRecording(ctx, eventID, duration, chatID, threadID, replyTo)
 }